@@ -15,6 +15,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/todoist/backend/api-gateway/internal/config"
 	"github.com/todoist/backend/api-gateway/internal/middleware"
+	"github.com/todoist/backend/pkg/deadline"
 	"github.com/todoist/backend/pkg/logger"
 )
 
@@ -69,7 +70,15 @@ func main() {
 		req.URL.Path = strings.TrimPrefix(req.URL.Path, "/v1")
 		req.Host = taskServiceURL.Host
 	}
+	// Signed attachment downloads carry their own signature instead of a token
+	r.PathPrefix("/v1/tasks/attachments/blobs/").Handler(taskProxy)
+	// Calendar apps cannot send a JWT, the feed authenticates with its own token
+	r.Path("/v1/tasks/calendar.ics").Handler(taskProxy)
+	// Imports and uploads send large files over minutes
+	r.Path("/v1/tasks/import").Handler(middleware.Auth(cfg.JWTSecret)(deadline.Middleware(30*time.Minute, log)(taskProxy)))
+	r.Path("/v1/tasks/{id}/attachments").Methods("POST").Handler(middleware.Auth(cfg.JWTSecret)(deadline.Middleware(10*time.Minute, log)(taskProxy)))
 	r.PathPrefix("/v1/tasks").Handler(middleware.Auth(cfg.JWTSecret)(taskProxy))
+	r.Path("/v1/sync").Handler(middleware.Auth(cfg.JWTSecret)(taskProxy))
 
 	// Project service routes (protected)
 	projectProxy := httputil.NewSingleHostReverseProxy(projectServiceURL)
@@ -0,0 +1,43 @@
+// Package deadline lets slow requests, such as imports and uploads, run
+// past the server's read and write timeouts
+package deadline
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/todoist/backend/pkg/logger"
+)
+
+// Extend moves the read and write deadlines of the connection serving w to
+// timeout from now. It fails when w cannot reach the connection, e.g. behind
+// a wrapper without an Unwrap method.
+func Extend(w http.ResponseWriter, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	controller := http.NewResponseController(w)
+	if err := controller.SetReadDeadline(deadline); err != nil {
+		return fmt.Errorf("failed to extend read deadline: %w", err)
+	}
+	if err := controller.SetWriteDeadline(deadline); err != nil {
+		return fmt.Errorf("failed to extend write deadline: %w", err)
+	}
+	return nil
+}
+
+// Middleware extends the deadlines of the requests it serves. A request
+// whose deadlines cannot be extended is still served, under the server's
+// timeouts, and a warning is logged.
+func Middleware(timeout time.Duration, log *logger.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := Extend(w, timeout); err != nil {
+				log.WithFields(map[string]interface{}{
+					"path": r.URL.Path,
+				}).WithError(err).Warn("failed to extend request deadline")
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
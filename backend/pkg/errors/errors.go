@@ -36,6 +36,7 @@ const (
 	CodeInternalError     = "INTERNAL_ERROR"
 	CodeBadRequest        = "BAD_REQUEST"
 	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	CodePayloadTooLarge   = "PAYLOAD_TOO_LARGE"
 )
 
 // NewValidationError creates a validation error
@@ -101,3 +102,13 @@ func NewBadRequestError(message string) *AppError {
 		StatusCode: http.StatusBadRequest,
 	}
 }
+
+// NewPayloadTooLargeError creates an error for a request body over its size
+// limit
+func NewPayloadTooLargeError(message string) *AppError {
+	return &AppError{
+		Code:       CodePayloadTooLarge,
+		Message:    message,
+		StatusCode: http.StatusRequestEntityTooLarge,
+	}
+}
@@ -82,6 +82,49 @@ func NewTaskDeleted(userID, taskID, projectID uuid.UUID) TaskDeleted {
 	}
 }
 
+// TaskRestored event published when a task is restored from the trash
+type TaskRestored struct {
+	BaseEvent
+	TaskID    uuid.UUID `json:"task_id"`
+	ProjectID uuid.UUID `json:"project_id"`
+}
+
+// NewTaskRestored creates a new TaskRestored event
+func NewTaskRestored(userID, taskID, projectID uuid.UUID) TaskRestored {
+	return TaskRestored{
+		BaseEvent: NewBaseEvent("task.task.restored", userID),
+		TaskID:    taskID,
+		ProjectID: projectID,
+	}
+}
+
+// TaskReminderDue event published when a task reminder fires. The event is
+// delivered at least once; a redelivery carries the same event ID, also set
+// as the AMQP MessageId, which consumers must de-duplicate on before
+// notifying the user.
+type TaskReminderDue struct {
+	BaseEvent
+	ReminderID uuid.UUID  `json:"reminder_id"`
+	TaskID     uuid.UUID  `json:"task_id"`
+	ProjectID  uuid.UUID  `json:"project_id"`
+	Title      string     `json:"title"`
+	DueDate    *time.Time `json:"due_date,omitempty"`
+	RemindAt   time.Time  `json:"remind_at"`
+}
+
+// NewTaskReminderDue creates a new TaskReminderDue event
+func NewTaskReminderDue(userID, reminderID, taskID, projectID uuid.UUID, title string, dueDate *time.Time, remindAt time.Time) TaskReminderDue {
+	return TaskReminderDue{
+		BaseEvent:  NewBaseEvent("task.reminder.due", userID),
+		ReminderID: reminderID,
+		TaskID:     taskID,
+		ProjectID:  projectID,
+		Title:      title,
+		DueDate:    dueDate,
+		RemindAt:   remindAt,
+	}
+}
+
 // CommentAdded event published when a comment is added to a task
 type CommentAdded struct {
 	BaseEvent
@@ -0,0 +1,30 @@
+package dto
+
+// ListActivityRequest holds the query parameters of the activity feeds.
+// TaskID limits the feed to one task; Cursor is the next_cursor of the
+// previous page.
+type ListActivityRequest struct {
+	TaskID string
+	Limit  int
+	Cursor string
+}
+
+type FieldChangeResponse struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+type ActivityResponse struct {
+	ID        string                         `json:"id"`
+	TaskID    string                         `json:"task_id"`
+	UserID    string                         `json:"user_id"`
+	Action    string                         `json:"action"`
+	Changes   map[string]FieldChangeResponse `json:"changes"`
+	CreatedAt string                         `json:"created_at"`
+}
+
+type ActivityListResponse struct {
+	Data       []*ActivityResponse `json:"data"`
+	NextCursor *string             `json:"next_cursor"`
+	HasMore    bool                `json:"has_more"`
+}
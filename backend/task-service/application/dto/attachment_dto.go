@@ -0,0 +1,26 @@
+package dto
+
+import "io"
+
+// UploadAttachmentRequest is a file uploaded to POST /tasks/{id}/attachments.
+// Content is read once, as it arrives.
+type UploadAttachmentRequest struct {
+	FileName  string
+	CommentID *string
+	Content   io.Reader
+}
+
+// AttachmentResponse is the API form of an attachment. DownloadURL is a
+// signed link that works without credentials until DownloadURLExpiresAt.
+type AttachmentResponse struct {
+	ID                   string  `json:"id"`
+	TaskID               string  `json:"task_id"`
+	CommentID            *string `json:"comment_id"`
+	FileName             string  `json:"file_name"`
+	ContentType          string  `json:"content_type"`
+	Size                 int64   `json:"size"`
+	Checksum             string  `json:"checksum"`
+	DownloadURL          string  `json:"download_url"`
+	DownloadURLExpiresAt string  `json:"download_url_expires_at"`
+	CreatedAt            string  `json:"created_at"`
+}
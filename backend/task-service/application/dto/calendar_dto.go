@@ -0,0 +1,19 @@
+package dto
+
+// CalendarTokenResponse carries a new calendar feed token. It is only
+// shown once: the service keeps a hash of it.
+type CalendarTokenResponse struct {
+	Token     string `json:"token"`
+	URL       string `json:"url"`
+	CreatedAt string `json:"created_at"`
+}
+
+// CalendarFeedRequest holds the query of GET /tasks/calendar.ics. Labels
+// keeps the tasks with any of them; Components is "todo", "event" or
+// "both", the default.
+type CalendarFeedRequest struct {
+	Token      string
+	ProjectID  *string
+	Labels     []string
+	Components string
+}
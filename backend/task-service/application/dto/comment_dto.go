@@ -0,0 +1,18 @@
+package dto
+
+type CreateCommentRequest struct {
+	Content string `json:"content" validate:"required,max=15000"`
+}
+
+type UpdateCommentRequest struct {
+	Content string `json:"content" validate:"required,max=15000"`
+}
+
+type CommentResponse struct {
+	ID        string `json:"id"`
+	TaskID    string `json:"task_id"`
+	UserID    string `json:"user_id"`
+	Content   string `json:"content"`
+	CreatedAt string `json:"created_at"`
+	UpdatedAt string `json:"updated_at"`
+}
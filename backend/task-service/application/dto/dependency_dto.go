@@ -0,0 +1,19 @@
+package dto
+
+// AddDependencyRequest is the body of POST /tasks/{id}/dependencies
+type AddDependencyRequest struct {
+	BlockedByID string `json:"blocked_by_id" validate:"required"`
+}
+
+type DependencyResponse struct {
+	TaskID      string `json:"task_id"`
+	BlockedByID string `json:"blocked_by_id"`
+	CreatedAt   string `json:"created_at"`
+}
+
+// TaskDependenciesResponse lists the tasks a task waits on and the tasks
+// waiting on it; trashed tasks are left out
+type TaskDependenciesResponse struct {
+	BlockedBy []*TaskResponse `json:"blocked_by"`
+	Blocking  []*TaskResponse `json:"blocking"`
+}
@@ -0,0 +1,46 @@
+package dto
+
+import "io"
+
+// ImportTasksRequest is an upload to POST /tasks/import. Format is
+// "todoist" for Todoist's CSV template, the default, or "csv" for a generic
+// CSV whose columns Mapping assigns to task fields (field to column
+// header); without a mapping, columns named after fields are used. Top
+// level tasks go to ProjectID, and dates without a zone are read in
+// Timezone. Content is read as the rows are imported.
+type ImportTasksRequest struct {
+	Format    string
+	DryRun    bool
+	ProjectID *string
+	Timezone  string
+	Mapping   map[string]string
+	Content   io.Reader
+}
+
+// ImportTasksResponse sums up an import. Rows counts the rows read, not
+// counting the header and blank lines; in a dry run Created counts the
+// tasks that would be created. Results previews the first rows and Errors
+// lists the rows that failed.
+type ImportTasksResponse struct {
+	DryRun          bool               `json:"dry_run"`
+	Rows            int                `json:"rows"`
+	Created         int                `json:"created"`
+	Duplicates      int                `json:"duplicates"`
+	Skipped         int                `json:"skipped"`
+	Failed          int                `json:"failed"`
+	Results         []*ImportRowResult `json:"results"`
+	Errors          []*ImportRowResult `json:"errors"`
+	ErrorsTruncated bool               `json:"errors_truncated"`
+}
+
+// ImportRowResult is what happened to the row at line Row of the file:
+// created, duplicate (imported before), skipped (not a task) or failed.
+// Error explains skipped and failed rows.
+type ImportRowResult struct {
+	Row       int     `json:"row"`
+	Status    string  `json:"status"`
+	Title     string  `json:"title,omitempty"`
+	TaskID    *string `json:"task_id,omitempty"`
+	ParentRow *int    `json:"parent_row,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
@@ -0,0 +1,21 @@
+package dto
+
+// CreateReminderRequest is the body of POST /tasks/{id}/reminders. Exactly
+// one of RemindAt, an RFC3339 time, and MinutesBefore, an offset before the
+// task's due time, is given.
+type CreateReminderRequest struct {
+	RemindAt      *string `json:"remind_at"`
+	MinutesBefore *int    `json:"minutes_before"`
+}
+
+// ReminderResponse is the API form of a reminder. FireAt is when it fires
+// next, null when nothing is scheduled.
+type ReminderResponse struct {
+	ID            string  `json:"id"`
+	TaskID        string  `json:"task_id"`
+	RemindAt      *string `json:"remind_at"`
+	MinutesBefore *int    `json:"minutes_before"`
+	FireAt        *string `json:"fire_at"`
+	FiredAt       *string `json:"fired_at"`
+	CreatedAt     string  `json:"created_at"`
+}
@@ -0,0 +1,56 @@
+package dto
+
+// StatsRequest holds the query of GET /tasks/stats: how many recent days
+// and weeks to list, 0 for the defaults
+type StatsRequest struct {
+	Days  int
+	Weeks int
+}
+
+// UpdateStatsGoalsRequest is the body of PUT /tasks/stats/goals; fields
+// left out keep their value. Timezone is the IANA zone completions are
+// counted into days in.
+type UpdateStatsGoalsRequest struct {
+	DailyGoal  *int    `json:"daily_goal"`
+	WeeklyGoal *int    `json:"weekly_goal"`
+	Timezone   *string `json:"timezone"`
+}
+
+// StatsResponse is a user's productivity: karma, goals, completions per
+// recent day and week, most recent first, and streaks of reached goals
+type StatsResponse struct {
+	Karma          int                    `json:"karma"`
+	CompletedCount int                    `json:"completed_count"`
+	DailyGoal      int                    `json:"daily_goal"`
+	WeeklyGoal     int                    `json:"weekly_goal"`
+	Timezone       string                 `json:"timezone"`
+	Today          *StatsPeriodResponse   `json:"today"`
+	ThisWeek       *StatsPeriodResponse   `json:"this_week"`
+	Days           []*StatsPeriodResponse `json:"days"`
+	Weeks          []*StatsPeriodResponse `json:"weeks"`
+	DailyStreak    *StreaksResponse       `json:"daily_streak"`
+	WeeklyStreak   *StreaksResponse       `json:"weekly_streak"`
+}
+
+// StatsPeriodResponse counts the completions of a day, or of the week
+// starting on Date
+type StatsPeriodResponse struct {
+	Date        string `json:"date"`
+	Completed   int    `json:"completed"`
+	Goal        int    `json:"goal"`
+	GoalReached bool   `json:"goal_reached"`
+}
+
+// StreaksResponse holds the running and the longest streak
+type StreaksResponse struct {
+	Current *StreakResponse `json:"current"`
+	Longest *StreakResponse `json:"longest"`
+}
+
+// StreakResponse is a run of days or weeks that reached their goal; Start
+// and End are omitted for an empty streak
+type StreakResponse struct {
+	Count int     `json:"count"`
+	Start *string `json:"start,omitempty"`
+	End   *string `json:"end,omitempty"`
+}
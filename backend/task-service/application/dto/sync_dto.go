@@ -0,0 +1,48 @@
+package dto
+
+import "encoding/json"
+
+// SyncRequest is the body of POST /sync. SyncToken is the sync_token of the
+// previous response; an empty token or "*" asks for a full sync. Commands
+// are applied in order before the changes are read.
+type SyncRequest struct {
+	SyncToken string        `json:"sync_token"`
+	Commands  []SyncCommand `json:"commands"`
+}
+
+// SyncCommand is a write a client queued, possibly while offline. UUID,
+// generated by the client, identifies the command across retries. A
+// task_add carries a TempID the following commands can use in place of the
+// task's ID, in id, parent_id, before and after; Args holds the ID with the
+// body of the matching batch operation.
+type SyncCommand struct {
+	Type   string          `json:"type"`
+	UUID   string          `json:"uuid"`
+	TempID string          `json:"temp_id"`
+	Args   json.RawMessage `json:"args"`
+}
+
+// SyncResponse carries the tasks changed since the request's token, which
+// replace the client's copies, and the tasks deleted since. SyncStatus holds
+// the outcome of each command by UUID; TempIDMapping the IDs of the tasks
+// created for temp IDs.
+type SyncResponse struct {
+	SyncToken     string                        `json:"sync_token"`
+	FullSync      bool                          `json:"full_sync"`
+	Tasks         []*TaskResponse               `json:"tasks"`
+	DeletedTasks  []*DeletedTaskResponse        `json:"deleted_tasks"`
+	SyncStatus    map[string]*SyncCommandStatus `json:"sync_status"`
+	TempIDMapping map[string]string             `json:"temp_id_mapping"`
+}
+
+type DeletedTaskResponse struct {
+	ID        string `json:"id"`
+	DeletedAt string `json:"deleted_at"`
+}
+
+// SyncCommandStatus reports a command with the HTTP status its single task
+// endpoint would have answered
+type SyncCommandStatus struct {
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
@@ -1,31 +1,236 @@
 package dto
 
+import "encoding/json"
+
+// CreateTaskRequest is the body of POST /tasks. DueDate ("2024-05-17")
+// makes an all-day task; DueDatetime, RFC3339 or a local time read in
+// DueTimezone, a timed one and takes precedence.
 type CreateTaskRequest struct {
-	Title       string  `json:"title" validate:"required"`
-	Description string  `json:"description"`
-	Priority    int     `json:"priority"`
-	ProjectID   *string `json:"project_id"`
-	DueDate     *string `json:"due_date"`
+	Title            string   `json:"title" validate:"required"`
+	Description      string   `json:"description"`
+	Priority         int      `json:"priority"`
+	ProjectID        *string  `json:"project_id"`
+	ParentID         *string  `json:"parent_id"`
+	DueDate          *string  `json:"due_date"`
+	DueDatetime      *string  `json:"due_datetime"`
+	DueTimezone      *string  `json:"due_timezone"`
+	Recurrence       *string  `json:"recurrence"`
+	Labels           []string `json:"labels"`
+	EstimatedMinutes *int     `json:"estimated_minutes"`
 }
 
+// UpdateTaskRequest is the body of PUT /tasks/{id}. The due fields work as
+// in CreateTaskRequest; empty strings remove the due date, and a zero
+// EstimatedMinutes removes the estimate.
 type UpdateTaskRequest struct {
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Status      string  `json:"status"`
-	Priority    int     `json:"priority"`
-	ProjectID   *string `json:"project_id"`
-	DueDate     *string `json:"due_date"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	Status           string   `json:"status"`
+	Priority         int      `json:"priority"`
+	ProjectID        *string  `json:"project_id"`
+	DueDate          *string  `json:"due_date"`
+	DueDatetime      *string  `json:"due_datetime"`
+	DueTimezone      *string  `json:"due_timezone"`
+	Recurrence       *string  `json:"recurrence"`
+	Labels           []string `json:"labels"`
+	EstimatedMinutes *int     `json:"estimated_minutes"`
+	// Force completes a task that still waits on open blockers; it is the
+	// force query parameter
+	Force bool `json:"-"`
+}
+
+// PatchTaskRequest is the body of PATCH /tasks/{id}, a JSON Merge Patch or a
+// JSON Patch as selected by ContentType. Force works as in
+// UpdateTaskRequest.
+type PatchTaskRequest struct {
+	ContentType string
+	Body        []byte
+	Force       bool
+}
+
+// TaskFilterParams are the filtering query parameters shared by task
+// listings and search. Filter is a Todoist-style filter expression such as
+// "(p1 | p2) & overdue & #Work"; its days are those of Timezone, an IANA
+// zone that defaults to UTC. Blocked selects tasks that do or do not wait
+// on open blockers.
+type TaskFilterParams struct {
+	Status    string
+	Priority  *int
+	ProjectID *string
+	Labels    []string
+	Blocked   *bool
+	Filter    string
+	Timezone  string
+}
+
+// ListTasksRequest holds the query parameters of GET /tasks. Cursor is the
+// next_cursor of the previous page.
+type ListTasksRequest struct {
+	TaskFilterParams
+	Sort      string
+	Direction string
+	Limit     int
+	Cursor    string
+}
+
+// SearchTasksRequest holds the query parameters of GET /tasks/search
+type SearchTasksRequest struct {
+	TaskFilterParams
+	Query string
+	Limit int
 }
 
+// CompletedTasksRequest holds the query parameters of GET /tasks/completed.
+// From and To are inclusive dates ("2024-05-17") in Timezone, an IANA zone
+// that defaults to UTC; leaving one out opens that end of the range. Cursor
+// is the next_cursor of the previous page.
+type CompletedTasksRequest struct {
+	From      string
+	To        string
+	Timezone  string
+	ProjectID *string
+	Limit     int
+	Cursor    string
+}
+
+// MoveTaskRequest is the body of POST /tasks/{id}/move. ParentID and
+// ProjectID re-parent the task; Before or After place it right before or
+// after another task in that task's project or inbox. A request with only
+// an anchor keeps the parent when the project stays the same.
+type MoveTaskRequest struct {
+	ParentID  *string `json:"parent_id"`
+	ProjectID *string `json:"project_id"`
+	Before    *string `json:"before"`
+	After     *string `json:"after"`
+}
+
+// BatchTasksRequest is the body of POST /tasks/batch. Mode is "atomic", the
+// default, or "best_effort".
+type BatchTasksRequest struct {
+	Mode       string               `json:"mode"`
+	Operations []BatchTaskOperation `json:"operations"`
+}
+
+// BatchTaskOperation is one item of a batch. Data holds the body the single
+// task endpoint of the operation takes; Version works like If-Match and
+// Force like the force query parameter of update and complete.
+type BatchTaskOperation struct {
+	Op      string          `json:"op"`
+	TaskID  string          `json:"task_id"`
+	Version *int            `json:"version"`
+	Force   bool            `json:"force"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// TaskResponse is the API form of a task. DueDate is the day the task is
+// due in its time zone; DueDatetime and DueTimezone are null for all-day
+// tasks.
 type TaskResponse struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	Status           string   `json:"status"`
+	Priority         int      `json:"priority"`
+	UserID           string   `json:"user_id"`
+	ProjectID        *string  `json:"project_id"`
+	ParentID         *string  `json:"parent_id"`
+	DueDate          *string  `json:"due_date"`
+	DueDatetime      *string  `json:"due_datetime"`
+	DueTimezone      *string  `json:"due_timezone"`
+	Recurrence       *string  `json:"recurrence"`
+	Labels           []string `json:"labels"`
+	Position         string   `json:"position"`
+	EstimatedMinutes *int     `json:"estimated_minutes"`
+	CompletedAt      *string  `json:"completed_at"`
+	CreatedAt        string   `json:"created_at"`
+	UpdatedAt        string   `json:"updated_at"`
+	Version          int      `json:"version"`
+	DeletedAt        *string  `json:"deleted_at,omitempty"`
+}
+
+type TaskListResponse struct {
+	Data       []*TaskResponse `json:"data"`
+	Total      int             `json:"total"`
+	NextCursor *string         `json:"next_cursor"`
+	HasMore    bool            `json:"has_more"`
+}
+
+type TaskSearchResultResponse struct {
+	*TaskResponse
+	Rank       float64              `json:"rank"`
+	Highlights TaskSearchHighlights `json:"highlights"`
+}
+
+// TaskSearchHighlights hold HTML-escaped excerpts with matches wrapped in
+// <mark> tags; fields without a match are empty
+type TaskSearchHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Comment     string `json:"comment"`
+}
+
+type BatchTasksResponse struct {
+	Mode    string             `json:"mode"`
+	Results []*BatchTaskResult `json:"results"`
+}
+
+// BatchTaskResult reports the outcome of one operation with the HTTP status
+// the single task endpoint would have answered
+type BatchTaskResult struct {
+	Index  int           `json:"index"`
+	Op     string        `json:"op"`
+	Status int           `json:"status"`
+	Task   *TaskResponse `json:"task,omitempty"`
+	Error  string        `json:"error,omitempty"`
+}
+
+type TaskTreeResponse struct {
+	*TaskResponse
+	Children []*TaskTreeResponse `json:"children"`
+}
+
+type TaskCompletionResponse struct {
 	ID          string  `json:"id"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	Status      string  `json:"status"`
-	Priority    int     `json:"priority"`
-	UserID      string  `json:"user_id"`
-	ProjectID   *string `json:"project_id"`
+	TaskID      string  `json:"task_id"`
 	DueDate     *string `json:"due_date"`
-	CreatedAt   string  `json:"created_at"`
-	UpdatedAt   string  `json:"updated_at"`
+	CompletedAt string  `json:"completed_at"`
+}
+
+// QuickAddTaskRequest is the body of POST /tasks/quick. Text is a line such
+// as "Call mom tomorrow 5pm p1 #Family @phone"; Timezone is the IANA zone
+// relative dates are read in, UTC by default.
+type QuickAddTaskRequest struct {
+	Text     string `json:"text" validate:"required"`
+	Timezone string `json:"timezone"`
+}
+
+// QuickAddTaskResponse holds the created task along with what was read from
+// the text. Spans locate the extracted parts so clients can highlight them.
+type QuickAddTaskResponse struct {
+	Task      *TaskResponse     `json:"task"`
+	Extracted QuickAddExtracted `json:"extracted"`
+	Spans     []QuickAddSpan    `json:"spans"`
+}
+
+// QuickAddExtracted lists the values read from quick add text. DueDate is
+// set for an all-day task and DueDatetime, in the requested timezone, when
+// a time was given.
+type QuickAddExtracted struct {
+	Title       string   `json:"title"`
+	DueDate     *string  `json:"due_date"`
+	DueDatetime *string  `json:"due_datetime"`
+	Recurrence  *string  `json:"recurrence"`
+	Priority    *int     `json:"priority"`
+	Project     *string  `json:"project"`
+	ProjectID   *string  `json:"project_id"`
+	Labels      []string `json:"labels"`
+}
+
+// QuickAddSpan is an extracted part of the text. Start and End are
+// character offsets; End is exclusive.
+type QuickAddSpan struct {
+	Type  string `json:"type"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Text  string `json:"text"`
 }
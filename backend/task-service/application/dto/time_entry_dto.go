@@ -0,0 +1,58 @@
+package dto
+
+// StartTimerRequest is the optional body of POST /tasks/{id}/timer/start
+type StartTimerRequest struct {
+	Note string `json:"note"`
+}
+
+// CreateTimeEntryRequest is the body of POST /tasks/{id}/time-entries, time
+// tracked without a timer. StartedAt and StoppedAt are RFC3339.
+type CreateTimeEntryRequest struct {
+	StartedAt string `json:"started_at" validate:"required"`
+	StoppedAt string `json:"stopped_at" validate:"required"`
+	Note      string `json:"note"`
+}
+
+// TimeEntryResponse is the API form of a time entry. StoppedAt is null and
+// DurationSeconds counts up to now while the timer runs.
+type TimeEntryResponse struct {
+	ID              string  `json:"id"`
+	TaskID          string  `json:"task_id"`
+	StartedAt       string  `json:"started_at"`
+	StoppedAt       *string `json:"stopped_at"`
+	DurationSeconds int64   `json:"duration_seconds"`
+	Running         bool    `json:"running"`
+	Note            string  `json:"note"`
+	CreatedAt       string  `json:"created_at"`
+}
+
+// TimeReportRequest holds the parameters of GET /tasks/time-report. GroupBy
+// is project, label or day. From and To are inclusive dates
+// ("2024-05-17") in Timezone, an IANA zone that defaults to UTC; the range
+// defaults to the last seven days.
+type TimeReportRequest struct {
+	GroupBy  string
+	From     string
+	To       string
+	Timezone string
+}
+
+// TimeReportResponse totals the tracked time by group. Time on a task with
+// several labels shows up under each of them but once in TotalSeconds.
+type TimeReportResponse struct {
+	GroupBy      string             `json:"group_by"`
+	From         string             `json:"from"`
+	To           string             `json:"to"`
+	Timezone     string             `json:"timezone"`
+	TotalSeconds int64              `json:"total_seconds"`
+	Data         []*TimeReportGroup `json:"data"`
+}
+
+// TimeReportGroup is the time tracked in one project, label or day. Key is
+// the project ID, label name or date, null for tasks in the inbox or
+// without labels. Entries counts the time entries that contributed.
+type TimeReportGroup struct {
+	Key     *string `json:"key"`
+	Seconds int64   `json:"seconds"`
+	Entries int     `json:"entries"`
+}
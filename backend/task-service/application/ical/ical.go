@@ -0,0 +1,208 @@
+// Package ical writes RFC 5545 iCalendar data
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// maxLineOctets is the longest a content line may be before it is
+	// folded
+	maxLineOctets = 75
+	dateLayout    = "20060102"
+	localLayout   = "20060102T150405"
+	utcLayout     = "20060102T150405Z"
+)
+
+// Writer writes content lines, folding long ones. The first write error is
+// kept and returned by Flush.
+type Writer struct {
+	w   *bufio.Writer
+	err error
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: bufio.NewWriter(w)}
+}
+
+// Begin opens a component such as VCALENDAR or VTODO
+func (w *Writer) Begin(component string) {
+	w.Line("BEGIN:" + component)
+}
+
+// End closes a component
+func (w *Writer) End(component string) {
+	w.Line("END:" + component)
+}
+
+// Property writes a property whose value is already encoded. params, such
+// as "VALUE=DATE", go between the name and the value.
+func (w *Writer) Property(name, value string, params ...string) {
+	line := name
+	for _, param := range params {
+		line += ";" + param
+	}
+	w.Line(line + ":" + value)
+}
+
+// Text writes a TEXT property, escaping its value
+func (w *Writer) Text(name, value string) {
+	w.Property(name, EscapeText(value))
+}
+
+// Line writes a content line, folded after 75 octets without splitting a
+// UTF-8 sequence
+func (w *Writer) Line(line string) {
+	if w.err != nil {
+		return
+	}
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		if _, w.err = w.w.WriteString(line[:cut] + "\r\n "); w.err != nil {
+			return
+		}
+		line = line[cut:]
+		// The leading space of a continuation line counts towards its length
+		limit = maxLineOctets - 1
+	}
+	_, w.err = w.w.WriteString(line + "\r\n")
+}
+
+// Flush writes out buffered lines and reports the first error
+func (w *Writer) Flush() error {
+	if w.err != nil {
+		return w.err
+	}
+	return w.w.Flush()
+}
+
+// EscapeText escapes a TEXT value
+func EscapeText(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", "",
+	).Replace(value)
+}
+
+// Date formats a DATE value, to go with the VALUE=DATE parameter
+func Date(t time.Time) string {
+	return t.Format(dateLayout)
+}
+
+// UTC formats a DATE-TIME value in UTC
+func UTC(t time.Time) string {
+	return t.UTC().Format(utcLayout)
+}
+
+// Local formats a DATE-TIME value as wall clock time, to go with a TZID
+// parameter naming t's location
+func Local(t time.Time) string {
+	return t.Format(localLayout)
+}
+
+// Timezone writes a VTIMEZONE for loc with the daylight saving rules in
+// effect in year, each repeating yearly on the same weekday of the month.
+// A zone without daylight saving time gets a single STANDARD observance.
+func (w *Writer) Timezone(loc *time.Location, year int) {
+	w.Begin("VTIMEZONE")
+	w.Property("TZID", loc.String())
+
+	transitions := zoneTransitions(loc, year)
+	if len(transitions) == 0 {
+		name, offset := time.Date(year, time.January, 1, 0, 0, 0, 0, loc).Zone()
+		w.Begin("STANDARD")
+		w.Property("DTSTART", "19700101T000000")
+		w.Property("TZOFFSETFROM", formatOffset(offset))
+		w.Property("TZOFFSETTO", formatOffset(offset))
+		w.Text("TZNAME", name)
+		w.End("STANDARD")
+	}
+
+	for _, transition := range transitions {
+		component := "STANDARD"
+		if transition.at.In(loc).IsDST() {
+			component = "DAYLIGHT"
+		}
+		name, offset := transition.at.In(loc).Zone()
+		// DTSTART is the wall clock time the change happens at, before it
+		start := transition.at.In(time.FixedZone("", transition.fromOffset))
+
+		w.Begin(component)
+		w.Property("DTSTART", Local(start))
+		w.Property("TZOFFSETFROM", formatOffset(transition.fromOffset))
+		w.Property("TZOFFSETTO", formatOffset(offset))
+		w.Property("RRULE", yearlyRule(start))
+		w.Text("TZNAME", name)
+		w.End(component)
+	}
+	w.End("VTIMEZONE")
+}
+
+// zoneTransition is a change of loc's UTC offset
+type zoneTransition struct {
+	at         time.Time
+	fromOffset int
+}
+
+// zoneTransitions finds the offset changes of loc in year by comparing the
+// offset at the start of each day, then narrowing each change to the
+// minute
+func zoneTransitions(loc *time.Location, year int) []zoneTransition {
+	var transitions []zoneTransition
+	day := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	_, offset := day.In(loc).Zone()
+	for day.Year() == year {
+		next := day.Add(24 * time.Hour)
+		_, nextOffset := next.In(loc).Zone()
+		if nextOffset != offset {
+			low, high := day, next
+			for high.Sub(low) > time.Minute {
+				mid := low.Add(high.Sub(low) / 2)
+				if _, midOffset := mid.In(loc).Zone(); midOffset == offset {
+					low = mid
+				} else {
+					high = mid
+				}
+			}
+			transitions = append(transitions, zoneTransition{at: high.Truncate(time.Minute), fromOffset: offset})
+			offset = nextOffset
+		}
+		day = next
+	}
+	return transitions
+}
+
+// yearlyRule repeats a date every year on the same weekday of its month,
+// counting from the end of the month in its last week
+func yearlyRule(t time.Time) string {
+	daysInMonth := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	n := (t.Day()-1)/7 + 1
+	if t.Day()+7 > daysInMonth {
+		n = -1
+	}
+	weekday := strings.ToUpper(t.Weekday().String()[:2])
+	return fmt.Sprintf("FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", int(t.Month()), n, weekday)
+}
+
+// formatOffset formats a UTC offset in seconds as +HHMM
+func formatOffset(offset int) string {
+	sign := "+"
+	if offset < 0 {
+		sign = "-"
+		offset = -offset
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, offset/3600, offset%3600/60)
+}
@@ -0,0 +1,25 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+func ToActivityResponse(activity *domain.Activity) *dto.ActivityResponse {
+	response := &dto.ActivityResponse{
+		ID:        activity.ID,
+		TaskID:    activity.TaskID,
+		UserID:    activity.UserID,
+		Action:    activity.Action,
+		Changes:   make(map[string]dto.FieldChangeResponse, len(activity.Changes)),
+		CreatedAt: activity.CreatedAt.Format(time.RFC3339),
+	}
+
+	for field, change := range activity.Changes {
+		response.Changes[field] = dto.FieldChangeResponse{Old: change.Old, New: change.New}
+	}
+
+	return response
+}
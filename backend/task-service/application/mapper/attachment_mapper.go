@@ -0,0 +1,25 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// ToAttachmentResponse converts an attachment along with a signed download
+// URL valid until expiresAt
+func ToAttachmentResponse(attachment *domain.Attachment, downloadURL string, expiresAt time.Time) *dto.AttachmentResponse {
+	return &dto.AttachmentResponse{
+		ID:                   attachment.ID,
+		TaskID:               attachment.TaskID,
+		CommentID:            attachment.CommentID,
+		FileName:             attachment.FileName,
+		ContentType:          attachment.ContentType,
+		Size:                 attachment.Size,
+		Checksum:             attachment.Checksum,
+		DownloadURL:          downloadURL,
+		DownloadURLExpiresAt: expiresAt.UTC().Format(time.RFC3339),
+		CreatedAt:            attachment.CreatedAt.Format(time.RFC3339),
+	}
+}
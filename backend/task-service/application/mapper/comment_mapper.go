@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+func ToCommentResponse(comment *domain.Comment) *dto.CommentResponse {
+	return &dto.CommentResponse{
+		ID:        comment.ID,
+		TaskID:    comment.TaskID,
+		UserID:    comment.UserID,
+		Content:   comment.Content,
+		CreatedAt: comment.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: comment.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,16 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+func ToDependencyResponse(dependency *domain.TaskDependency) *dto.DependencyResponse {
+	return &dto.DependencyResponse{
+		TaskID:      dependency.TaskID,
+		BlockedByID: dependency.BlockedByID,
+		CreatedAt:   dependency.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,19 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+func ToLabelResponse(label *domain.Label) *dto.LabelResponse {
+	return &dto.LabelResponse{
+		ID:        label.ID,
+		Name:      label.Name,
+		Color:     label.Color,
+		UserID:    label.UserID,
+		CreatedAt: label.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: label.UpdatedAt.Format(time.RFC3339),
+	}
+}
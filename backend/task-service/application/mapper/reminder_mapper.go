@@ -0,0 +1,29 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+func ToReminderResponse(reminder *domain.Reminder) *dto.ReminderResponse {
+	return &dto.ReminderResponse{
+		ID:            reminder.ID,
+		TaskID:        reminder.TaskID,
+		RemindAt:      formatTime(reminder.RemindAt),
+		MinutesBefore: reminder.MinutesBefore,
+		FireAt:        formatTime(reminder.FireAt),
+		FiredAt:       formatTime(reminder.FiredAt),
+		CreatedAt:     reminder.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// formatTime renders an optional time as RFC3339
+func formatTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
@@ -0,0 +1,37 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// ToStatsPeriodResponse converts the count of the day or week starting at
+// start
+func ToStatsPeriodResponse(start time.Time, completed, goal int) *dto.StatsPeriodResponse {
+	return &dto.StatsPeriodResponse{
+		Date:        start.Format(domain.DueDateLayout),
+		Completed:   completed,
+		Goal:        goal,
+		GoalReached: completed >= goal,
+	}
+}
+
+func ToStreaksResponse(current, longest domain.Streak) *dto.StreaksResponse {
+	return &dto.StreaksResponse{
+		Current: toStreakResponse(current),
+		Longest: toStreakResponse(longest),
+	}
+}
+
+func toStreakResponse(streak domain.Streak) *dto.StreakResponse {
+	response := &dto.StreakResponse{Count: streak.Count}
+	if streak.Count > 0 {
+		start := streak.Start.Format(domain.DueDateLayout)
+		end := streak.End.Format(domain.DueDateLayout)
+		response.Start = &start
+		response.End = &end
+	}
+	return response
+}
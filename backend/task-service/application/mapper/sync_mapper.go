@@ -0,0 +1,31 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// ToSyncResponse converts the changes read by a sync; the command results
+// are filled in by the caller
+func ToSyncResponse(changes *domain.SyncChanges, fullSync bool) *dto.SyncResponse {
+	response := &dto.SyncResponse{
+		SyncToken:     changes.Token,
+		FullSync:      fullSync,
+		Tasks:         make([]*dto.TaskResponse, 0, len(changes.Tasks)),
+		DeletedTasks:  make([]*dto.DeletedTaskResponse, 0, len(changes.Deleted)),
+		SyncStatus:    map[string]*dto.SyncCommandStatus{},
+		TempIDMapping: map[string]string{},
+	}
+	for _, task := range changes.Tasks {
+		response.Tasks = append(response.Tasks, ToTaskResponse(task))
+	}
+	for _, tombstone := range changes.Deleted {
+		response.DeletedTasks = append(response.DeletedTasks, &dto.DeletedTaskResponse{
+			ID:        tombstone.TaskID,
+			DeletedAt: tombstone.DeletedAt.Format(time.RFC3339),
+		})
+	}
+	return response
+}
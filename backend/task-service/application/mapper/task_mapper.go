@@ -15,18 +15,110 @@ func ToTaskResponse(task *domain.Task) *dto.TaskResponse {
 		Status:      task.Status,
 		Priority:    task.Priority,
 		UserID:      task.UserID,
+		Labels:      task.Labels,
+		Position:    task.Position,
 		CreatedAt:   task.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   task.UpdatedAt.Format(time.RFC3339),
+		Version:     task.Version,
+	}
+
+	if response.Labels == nil {
+		response.Labels = []string{}
 	}
 
 	if task.ProjectID != nil {
 		response.ProjectID = task.ProjectID
 	}
 
-	if task.DueDate != nil {
-		dueDateStr := task.DueDate.Format(time.RFC3339)
+	if task.ParentID != nil {
+		response.ParentID = task.ParentID
+	}
+
+	if due := task.LocalDue(); due != nil {
+		dueDateStr := due.Format(domain.DueDateLayout)
+		response.DueDate = &dueDateStr
+		if !task.DueAllDay {
+			dueDatetime := due.Format(time.RFC3339)
+			response.DueDatetime = &dueDatetime
+		}
+		if task.DueTimezone != "" {
+			dueTimezone := task.DueTimezone
+			response.DueTimezone = &dueTimezone
+		}
+	}
+
+	if task.RecurrenceRule != "" {
+		recurrence := task.RecurrenceRule
+		response.Recurrence = &recurrence
+	}
+
+	if task.EstimatedMinutes != nil {
+		estimate := *task.EstimatedMinutes
+		response.EstimatedMinutes = &estimate
+	}
+
+	if task.CompletedAt != nil {
+		completedAt := task.CompletedAt.Format(time.RFC3339)
+		response.CompletedAt = &completedAt
+	}
+
+	if task.DeletedAt != nil {
+		deletedAt := task.DeletedAt.Format(time.RFC3339)
+		response.DeletedAt = &deletedAt
+	}
+
+	return response
+}
+
+func ToTaskCompletionResponse(completion *domain.TaskCompletion) *dto.TaskCompletionResponse {
+	response := &dto.TaskCompletionResponse{
+		ID:          completion.ID,
+		TaskID:      completion.TaskID,
+		CompletedAt: completion.CompletedAt.Format(time.RFC3339),
+	}
+
+	if completion.DueDate != nil {
+		dueDateStr := completion.DueDate.Format(time.RFC3339)
 		response.DueDate = &dueDateStr
 	}
 
 	return response
 }
+
+// ToTaskSearchResultResponse converts a search match to its response DTO
+func ToTaskSearchResultResponse(result *domain.TaskSearchResult) *dto.TaskSearchResultResponse {
+	return &dto.TaskSearchResultResponse{
+		TaskResponse: ToTaskResponse(result.Task),
+		Rank:         result.Rank,
+		Highlights: dto.TaskSearchHighlights{
+			Title:       result.TitleHighlight,
+			Description: result.DescriptionHighlight,
+			Comment:     result.CommentHighlight,
+		},
+	}
+}
+
+// ToTaskTreeResponse nests a subtree returned by the repository, where the
+// first task is the root and every other task appears after its parent
+func ToTaskTreeResponse(subtree []*domain.Task) *dto.TaskTreeResponse {
+	if len(subtree) == 0 {
+		return nil
+	}
+
+	nodes := make(map[string]*dto.TaskTreeResponse, len(subtree))
+	root := &dto.TaskTreeResponse{TaskResponse: ToTaskResponse(subtree[0]), Children: []*dto.TaskTreeResponse{}}
+	nodes[subtree[0].ID] = root
+
+	for _, task := range subtree[1:] {
+		node := &dto.TaskTreeResponse{TaskResponse: ToTaskResponse(task), Children: []*dto.TaskTreeResponse{}}
+		nodes[task.ID] = node
+		if task.ParentID == nil {
+			continue
+		}
+		if parent, ok := nodes[*task.ParentID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	return root
+}
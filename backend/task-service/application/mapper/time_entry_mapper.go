@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// ToTimeEntryResponse converts a time entry, counting a running timer up to
+// now
+func ToTimeEntryResponse(entry *domain.TimeEntry, now time.Time) *dto.TimeEntryResponse {
+	return &dto.TimeEntryResponse{
+		ID:              entry.ID,
+		TaskID:          entry.TaskID,
+		StartedAt:       entry.StartedAt.Format(time.RFC3339),
+		StoppedAt:       formatTime(entry.StoppedAt),
+		DurationSeconds: int64(entry.Duration(now) / time.Second),
+		Running:         entry.IsRunning(),
+		Note:            entry.Note,
+		CreatedAt:       entry.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+func ToTimeReportResponse(req dto.TimeReportRequest, total time.Duration, groups []*domain.TimeTotal) *dto.TimeReportResponse {
+	response := &dto.TimeReportResponse{
+		GroupBy:      req.GroupBy,
+		From:         req.From,
+		To:           req.To,
+		Timezone:     req.Timezone,
+		TotalSeconds: int64(total / time.Second),
+		Data:         make([]*dto.TimeReportGroup, 0, len(groups)),
+	}
+	for _, group := range groups {
+		response.Data = append(response.Data, &dto.TimeReportGroup{
+			Key:     group.Key,
+			Seconds: int64(group.Duration / time.Second),
+			Entries: group.Entries,
+		})
+	}
+	return response
+}
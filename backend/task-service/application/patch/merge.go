@@ -0,0 +1,64 @@
+// Package patch applies JSON Merge Patch (RFC 7396) and JSON Patch
+// (RFC 6902) documents to decoded JSON values
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	MergePatchContentType = "application/merge-patch+json"
+	JSONPatchContentType  = "application/json-patch+json"
+)
+
+// Error describes a patch that cannot be applied
+type Error struct {
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func errorf(format string, args ...interface{}) *Error {
+	return &Error{Message: fmt.Sprintf(format, args...)}
+}
+
+// MergePatchFields decodes a merge patch and returns the top-level members
+// it sets; a patch that is not a JSON object cannot patch a document's
+// fields and is rejected
+func MergePatchFields(raw []byte) (map[string]interface{}, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return nil, errorf("merge patch must be a JSON object")
+	}
+	return fields, nil
+}
+
+// ApplyMergePatch applies a merge patch to target following RFC 7396: null
+// members remove, objects merge recursively and anything else replaces
+func ApplyMergePatch(target interface{}, patch interface{}) interface{} {
+	patchObject, ok := patch.(map[string]interface{})
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]interface{})
+	if !ok {
+		targetObject = map[string]interface{}{}
+	}
+
+	result := make(map[string]interface{}, len(targetObject))
+	for key, value := range targetObject {
+		result[key] = value
+	}
+	for key, value := range patchObject {
+		if value == nil {
+			delete(result, key)
+			continue
+		}
+		result[key] = ApplyMergePatch(result[key], value)
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+	"github.com/todoist/backend/pkg/events"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type AddCommentUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewAddCommentUseCase(transactor domain.Transactor) *AddCommentUseCase {
+	return &AddCommentUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute adds a comment to a task and records CommentAdded in the same
+// transaction
+func (uc *AddCommentUseCase) Execute(ctx context.Context, taskID, userID string, req dto.CreateCommentRequest) (*dto.CommentResponse, error) {
+	if req.Content == "" {
+		return nil, apperrors.NewBadRequestError("content is required")
+	}
+
+	now := time.Now()
+	comment := &domain.Comment{
+		ID:        uuid.New().String(),
+		TaskID:    taskID,
+		UserID:    userID,
+		Content:   req.Content,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		if _, err := getOwnedTask(repos.Tasks, taskID, userID); err != nil {
+			return err
+		}
+
+		if err := repos.Comments.Create(comment); err != nil {
+			return apperrors.NewInternalError("failed to create comment", err)
+		}
+
+		return recordEvent(repos.Outbox, events.NewCommentAdded(
+			parseUUID(userID), parseUUID(comment.ID), parseUUID(taskID), comment.Content,
+		))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToCommentResponse(comment), nil
+}
+
+// parseUUID converts an ID to the uuid.UUID used by events, yielding the nil
+// UUID for IDs that are not valid UUIDs
+func parseUUID(id string) uuid.UUID {
+	parsed, _ := uuid.Parse(id)
+	return parsed
+}
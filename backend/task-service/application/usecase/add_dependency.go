@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type AddDependencyUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewAddDependencyUseCase(transactor domain.Transactor) *AddDependencyUseCase {
+	return &AddDependencyUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute makes a task wait on another of the user's tasks. Dependencies
+// that would make a task wait on itself, directly or through other tasks,
+// are rejected.
+func (uc *AddDependencyUseCase) Execute(ctx context.Context, taskID, userID string, req dto.AddDependencyRequest) (*dto.DependencyResponse, error) {
+	var dependency *domain.TaskDependency
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		task, err := getOwnedTask(repos.Tasks, taskID, userID)
+		if err != nil {
+			return err
+		}
+		blocker, err := getOwnedTask(repos.Tasks, req.BlockedByID, userID)
+		if err != nil {
+			return err
+		}
+		if task.ID == blocker.ID {
+			return apperrors.NewBadRequestError("a task cannot be blocked by itself")
+		}
+
+		// Hold the user's dependency graph still between the check and the insert
+		if err := repos.Dependencies.LockUser(userID); err != nil {
+			return apperrors.NewInternalError("failed to lock dependencies", err)
+		}
+
+		_, err = repos.Dependencies.Get(task.ID, blocker.ID)
+		if err == nil {
+			return apperrors.NewConflictError("task is already blocked by this task")
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NewInternalError("failed to get dependency", err)
+		}
+
+		existing, err := repos.Dependencies.GetByUserID(userID)
+		if err != nil {
+			return apperrors.NewInternalError("failed to get dependencies", err)
+		}
+
+		dependency = &domain.TaskDependency{
+			TaskID:      task.ID,
+			BlockedByID: blocker.ID,
+			UserID:      userID,
+			CreatedAt:   time.Now(),
+		}
+		if err := domain.CheckDependencyCycle(existing, dependency); err != nil {
+			return apperrors.NewBadRequestError(err.Error())
+		}
+
+		if err := repos.Dependencies.Add(dependency); err != nil {
+			return apperrors.NewInternalError("failed to add dependency", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToDependencyResponse(dependency), nil
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// getTaskAttachment loads an attachment of an owned task
+func getTaskAttachment(taskRepo domain.TaskRepository, attachmentRepo domain.AttachmentRepository, taskID, attachmentID, userID string) (*domain.Attachment, error) {
+	if _, err := getOwnedTask(taskRepo, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	if attachmentID == "" {
+		return nil, apperrors.NewBadRequestError("attachment ID is required")
+	}
+
+	attachment, err := attachmentRepo.GetByID(attachmentID)
+	if err != nil || attachment.TaskID != taskID {
+		return nil, apperrors.NewNotFoundError("attachment not found")
+	}
+
+	return attachment, nil
+}
+
+// toAttachmentResponse converts an attachment with a fresh download URL
+func toAttachmentResponse(ctx context.Context, blobStore domain.BlobStore, attachment *domain.Attachment, urlExpiry time.Duration) (*dto.AttachmentResponse, error) {
+	expiresAt := time.Now().Add(urlExpiry)
+	downloadURL, err := blobStore.SignedURL(ctx, attachment.StorageKey, attachment.FileName, attachment.ContentType, urlExpiry)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to sign download URL", err)
+	}
+	return mapper.ToAttachmentResponse(attachment, downloadURL, expiresAt), nil
+}
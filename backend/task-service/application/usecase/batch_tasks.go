@@ -0,0 +1,268 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// Batch modes
+const (
+	BatchModeAtomic     = "atomic"
+	BatchModeBestEffort = "best_effort"
+)
+
+// Batch operations
+const (
+	BatchOpCreate   = "create"
+	BatchOpUpdate   = "update"
+	BatchOpComplete = "complete"
+	BatchOpReopen   = "reopen"
+	BatchOpMove     = "move"
+	BatchOpDelete   = "delete"
+)
+
+const maxBatchOperations = 100
+
+// BatchOperationError reports the operation that failed an atomic batch;
+// none of the batch was applied. Status and Message describe the failure
+// like a per-item result would.
+type BatchOperationError struct {
+	Index   int
+	Status  int
+	Message string
+	Err     error
+}
+
+func (e *BatchOperationError) Error() string {
+	return fmt.Sprintf("operation %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchOperationError) Unwrap() error {
+	return e.Err
+}
+
+type BatchTasksUseCase struct {
+	transactor   domain.Transactor
+	createTaskUC *CreateTaskUseCase
+	updateTaskUC *UpdateTaskUseCase
+	moveTaskUC   *MoveTaskUseCase
+	deleteTaskUC *DeleteTaskUseCase
+}
+
+func NewBatchTasksUseCase(transactor domain.Transactor) *BatchTasksUseCase {
+	return &BatchTasksUseCase{
+		transactor:   transactor,
+		createTaskUC: NewCreateTaskUseCase(transactor),
+		updateTaskUC: NewUpdateTaskUseCase(transactor),
+		moveTaskUC:   NewMoveTaskUseCase(transactor),
+		deleteTaskUC: NewDeleteTaskUseCase(transactor),
+	}
+}
+
+// batchOperation is a decoded dto.BatchTaskOperation
+type batchOperation struct {
+	op      string
+	taskID  string
+	version *int
+	force   bool
+	create  dto.CreateTaskRequest
+	update  dto.UpdateTaskRequest
+	move    dto.MoveTaskRequest
+}
+
+// Execute runs the operations in order, each through the same code and
+// ownership checks as its single task endpoint. In atomic mode they share
+// one transaction and the first failure rolls back the whole batch, reported
+// as a *BatchOperationError. In best-effort mode every operation commits on
+// its own and failures only show up in its result.
+func (uc *BatchTasksUseCase) Execute(ctx context.Context, userID string, req dto.BatchTasksRequest) (*dto.BatchTasksResponse, error) {
+	// Validate inputs
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+	mode := req.Mode
+	if mode == "" {
+		mode = BatchModeAtomic
+	}
+	if mode != BatchModeAtomic && mode != BatchModeBestEffort {
+		return nil, apperrors.NewBadRequestError("mode must be atomic or best_effort")
+	}
+	if len(req.Operations) == 0 {
+		return nil, apperrors.NewBadRequestError("operations are required")
+	}
+	if len(req.Operations) > maxBatchOperations {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("a batch holds at most %d operations", maxBatchOperations))
+	}
+
+	if mode == BatchModeAtomic {
+		return uc.executeAtomic(ctx, userID, req.Operations)
+	}
+	return uc.executeBestEffort(ctx, userID, req.Operations), nil
+}
+
+func (uc *BatchTasksUseCase) executeAtomic(ctx context.Context, userID string, items []dto.BatchTaskOperation) (*dto.BatchTasksResponse, error) {
+	// Decode everything up front so a malformed item fails before any write
+	ops := make([]batchOperation, len(items))
+	for i, item := range items {
+		op, err := parseBatchOperation(item)
+		if err != nil {
+			return nil, newBatchOperationError(i, err)
+		}
+		ops[i] = op
+	}
+
+	var results []*dto.BatchTaskResult
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		results = make([]*dto.BatchTaskResult, 0, len(ops))
+		for i, op := range ops {
+			task, err := uc.run(repos, userID, op)
+			if err != nil {
+				return newBatchOperationError(i, err)
+			}
+			results = append(results, batchSuccess(i, op.op, task))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.BatchTasksResponse{Mode: BatchModeAtomic, Results: results}, nil
+}
+
+func (uc *BatchTasksUseCase) executeBestEffort(ctx context.Context, userID string, items []dto.BatchTaskOperation) *dto.BatchTasksResponse {
+	response := &dto.BatchTasksResponse{
+		Mode:    BatchModeBestEffort,
+		Results: make([]*dto.BatchTaskResult, 0, len(items)),
+	}
+
+	for i, item := range items {
+		op, err := parseBatchOperation(item)
+		if err == nil {
+			var task *domain.Task
+			err = uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+				var err error
+				task, err = uc.run(repos, userID, op)
+				return err
+			})
+			if err == nil {
+				response.Results = append(response.Results, batchSuccess(i, op.op, task))
+				continue
+			}
+		}
+
+		status, message := batchFailure(err)
+		response.Results = append(response.Results, &dto.BatchTaskResult{
+			Index:  i,
+			Op:     item.Op,
+			Status: status,
+			Error:  message,
+		})
+	}
+
+	return response
+}
+
+// run performs one operation inside a transaction. The version of an item
+// applies to update, complete, reopen and delete. Completing a completed
+// task and reopening a pending one change nothing.
+func (uc *BatchTasksUseCase) run(repos domain.Repositories, userID string, op batchOperation) (*domain.Task, error) {
+	switch op.op {
+	case BatchOpCreate:
+		return uc.createTaskUC.create(repos, op.create, userID)
+	case BatchOpUpdate:
+		return uc.updateTaskUC.apply(repos, op.taskID, userID, op.update, op.version)
+	case BatchOpComplete, BatchOpReopen:
+		task, err := getOwnedTask(repos.Tasks, op.taskID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if err := checkTaskVersion(task, op.version); err != nil {
+			return nil, err
+		}
+		status := domain.TaskStatusCompleted
+		if op.op == BatchOpReopen {
+			status = domain.TaskStatusPending
+		}
+		return applyTaskChanges(repos, task, taskChanges{Status: &status, Force: op.force})
+	case BatchOpMove:
+		return uc.moveTaskUC.move(repos, op.taskID, userID, op.move)
+	default:
+		return nil, uc.deleteTaskUC.delete(repos, op.taskID, userID, op.version)
+	}
+}
+
+func parseBatchOperation(item dto.BatchTaskOperation) (batchOperation, error) {
+	op := batchOperation{op: item.Op, taskID: item.TaskID, version: item.Version, force: item.Force}
+
+	if item.Op != BatchOpCreate && item.TaskID == "" {
+		return op, apperrors.NewBadRequestError("task_id is required")
+	}
+
+	switch item.Op {
+	case BatchOpCreate:
+		return op, decodeBatchData(item.Data, &op.create)
+	case BatchOpUpdate:
+		if err := decodeBatchData(item.Data, &op.update); err != nil {
+			return op, err
+		}
+		op.update.Force = item.Force
+		return op, nil
+	case BatchOpMove:
+		return op, decodeBatchData(item.Data, &op.move)
+	case BatchOpComplete, BatchOpReopen, BatchOpDelete:
+		return op, nil
+	default:
+		return op, apperrors.NewBadRequestError(fmt.Sprintf("unknown op %q", item.Op))
+	}
+}
+
+func decodeBatchData(data json.RawMessage, v interface{}) error {
+	if len(data) == 0 {
+		return apperrors.NewBadRequestError("data is required")
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return apperrors.NewBadRequestError("invalid data")
+	}
+	return nil
+}
+
+func batchSuccess(index int, op string, task *domain.Task) *dto.BatchTaskResult {
+	result := &dto.BatchTaskResult{Index: index, Op: op, Status: http.StatusOK}
+	if op == BatchOpCreate {
+		result.Status = http.StatusCreated
+	}
+	if task != nil {
+		result.Task = mapper.ToTaskResponse(task)
+	}
+	return result
+}
+
+// batchFailure maps an operation error to the status and message its single
+// task endpoint would have answered with
+func batchFailure(err error) (int, string) {
+	var preconditionErr *PreconditionFailedError
+	if errors.As(err, &preconditionErr) {
+		return http.StatusPreconditionFailed, preconditionErr.Error()
+	}
+
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) && appErr.StatusCode != http.StatusInternalServerError {
+		return appErr.StatusCode, appErr.Message
+	}
+	return http.StatusInternalServerError, "operation failed"
+}
+
+func newBatchOperationError(index int, err error) *BatchOperationError {
+	status, message := batchFailure(err)
+	return &BatchOperationError{Index: index, Status: status, Message: message, Err: err}
+}
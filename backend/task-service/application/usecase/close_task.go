@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type CloseTaskUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewCloseTaskUseCase(transactor domain.Transactor) *CloseTaskUseCase {
+	return &CloseTaskUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute completes a pending task along with its subtasks and records
+// TaskCompleted. A recurring task with occurrences left moves on to its next
+// due date and stays pending. force completes a task that still waits on
+// open blockers; a non-nil ifMatch must equal the task's current version.
+func (uc *CloseTaskUseCase) Execute(ctx context.Context, taskID, userID string, force bool, ifMatch *int) (*dto.TaskResponse, error) {
+	var task *domain.Task
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		var err error
+		task, err = getOwnedTask(repos.Tasks, taskID, userID)
+		if err != nil {
+			return err
+		}
+		if err := checkTaskVersion(task, ifMatch); err != nil {
+			return err
+		}
+		if task.IsCompleted() {
+			return apperrors.NewConflictError("task is already completed")
+		}
+
+		status := domain.TaskStatusCompleted
+		task, err = applyTaskChanges(repos, task, taskChanges{Status: &status, Force: force})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToTaskResponse(task), nil
+}
@@ -0,0 +1,61 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// calendarTokenBytes is the entropy of a feed token
+const calendarTokenBytes = 32
+
+type CreateCalendarTokenUseCase struct {
+	feedRepo  domain.CalendarFeedRepository
+	publicURL string
+}
+
+// NewCreateCalendarTokenUseCase builds feed URLs under publicURL, the
+// externally visible base URL of the API
+func NewCreateCalendarTokenUseCase(feedRepo domain.CalendarFeedRepository, publicURL string) *CreateCalendarTokenUseCase {
+	return &CreateCalendarTokenUseCase{
+		feedRepo:  feedRepo,
+		publicURL: strings.TrimSuffix(publicURL, "/"),
+	}
+}
+
+// Execute creates a calendar feed token for the user, revoking the previous
+// one, and returns it with the feed URL to subscribe to
+func (uc *CreateCalendarTokenUseCase) Execute(ctx context.Context, userID string) (*dto.CalendarTokenResponse, error) {
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	secret := make([]byte, calendarTokenBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, apperrors.NewInternalError("failed to generate token", err)
+	}
+	token := base64.RawURLEncoding.EncodeToString(secret)
+
+	feed := &domain.CalendarFeed{
+		UserID:    userID,
+		TokenHash: domain.HashFeedToken(token),
+		CreatedAt: time.Now(),
+	}
+	if err := uc.feedRepo.Save(feed); err != nil {
+		return nil, apperrors.NewInternalError("failed to save calendar token", err)
+	}
+
+	return &dto.CalendarTokenResponse{
+		Token:     token,
+		URL:       uc.publicURL + "/tasks/calendar.ics?token=" + url.QueryEscape(token),
+		CreatedAt: feed.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
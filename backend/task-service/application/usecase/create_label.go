@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type CreateLabelUseCase struct {
+	labelRepo domain.LabelRepository
+}
+
+func NewCreateLabelUseCase(labelRepo domain.LabelRepository) *CreateLabelUseCase {
+	return &CreateLabelUseCase{
+		labelRepo: labelRepo,
+	}
+}
+
+func (uc *CreateLabelUseCase) Execute(ctx context.Context, req dto.CreateLabelRequest, userID string) (*dto.LabelResponse, error) {
+	name, err := domain.NormalizeLabelName(req.Name)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError(err.Error())
+	}
+
+	// Label names are unique per user
+	existing, err := uc.labelRepo.GetByNames(userID, []string{name})
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get labels", err)
+	}
+	if len(existing) > 0 {
+		return nil, apperrors.NewConflictError("a label with this name already exists")
+	}
+
+	color := req.Color
+	if color == "" {
+		color = domain.DefaultLabelColor
+	}
+
+	now := time.Now()
+	label := &domain.Label{
+		ID:        uuid.New().String(),
+		UserID:    userID,
+		Name:      name,
+		Color:     color,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := uc.labelRepo.Create(label); err != nil {
+		return nil, apperrors.NewInternalError("failed to create label", err)
+	}
+
+	return mapper.ToLabelResponse(label), nil
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// maxReminderOffset bounds how long before the due time a reminder fires
+const maxReminderOffset = 4 * 7 * 24 * 60 // minutes
+
+type CreateReminderUseCase struct {
+	taskRepo     domain.TaskRepository
+	reminderRepo domain.ReminderRepository
+}
+
+func NewCreateReminderUseCase(taskRepo domain.TaskRepository, reminderRepo domain.ReminderRepository) *CreateReminderUseCase {
+	return &CreateReminderUseCase{
+		taskRepo:     taskRepo,
+		reminderRepo: reminderRepo,
+	}
+}
+
+// Execute adds a reminder to a task. A relative reminder on a task without
+// a due time is kept and scheduled once the task gets one.
+func (uc *CreateReminderUseCase) Execute(ctx context.Context, taskID, userID string, req dto.CreateReminderRequest) (*dto.ReminderResponse, error) {
+	task, err := getOwnedTask(uc.taskRepo, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if (req.RemindAt == nil) == (req.MinutesBefore == nil) {
+		return nil, apperrors.NewBadRequestError("exactly one of remind_at and minutes_before is required")
+	}
+
+	now := time.Now()
+	reminder := &domain.Reminder{
+		ID:        uuid.New().String(),
+		TaskID:    task.ID,
+		UserID:    userID,
+		CreatedAt: now,
+	}
+
+	if req.RemindAt != nil {
+		remindAt, err := time.Parse(time.RFC3339, *req.RemindAt)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError("invalid remind_at format, should be RFC3339")
+		}
+		if !remindAt.After(now) {
+			return nil, apperrors.NewBadRequestError("remind_at must be in the future")
+		}
+		remindAt = remindAt.UTC()
+		reminder.RemindAt = &remindAt
+		reminder.FireAt = &remindAt
+	} else {
+		minutes := *req.MinutesBefore
+		if minutes < 0 || minutes > maxReminderOffset {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("minutes_before must be between 0 and %d", maxReminderOffset))
+		}
+		reminder.MinutesBefore = &minutes
+		reminder.Schedule(task, now)
+	}
+
+	if err := uc.reminderRepo.Create(reminder); err != nil {
+		return nil, apperrors.NewInternalError("failed to create reminder", err)
+	}
+
+	return mapper.ToReminderResponse(reminder), nil
+}
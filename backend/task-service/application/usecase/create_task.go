@@ -5,57 +5,118 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
 	"github.com/todoist/backend/task-service/application/dto"
 	"github.com/todoist/backend/task-service/application/mapper"
 	"github.com/todoist/backend/task-service/domain"
-	apperrors "github.com/todoist/backend/pkg/errors"
 )
 
 type CreateTaskUseCase struct {
-	taskRepo domain.TaskRepository
+	transactor domain.Transactor
 }
 
-func NewCreateTaskUseCase(taskRepo domain.TaskRepository) *CreateTaskUseCase {
+func NewCreateTaskUseCase(transactor domain.Transactor) *CreateTaskUseCase {
 	return &CreateTaskUseCase{
-		taskRepo: taskRepo,
+		transactor: transactor,
 	}
 }
 
 func (uc *CreateTaskUseCase) Execute(ctx context.Context, req dto.CreateTaskRequest, userID string) (*dto.TaskResponse, error) {
+	var task *domain.Task
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		var err error
+		task, err = uc.create(repos, req, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return response DTO
+	return mapper.ToTaskResponse(task), nil
+}
+
+// create builds and stores the task inside a transaction and records its
+// events
+func (uc *CreateTaskUseCase) create(repos domain.Repositories, req dto.CreateTaskRequest, userID string) (*domain.Task, error) {
 	// Validate required fields
 	if req.Title == "" {
 		return nil, apperrors.NewBadRequestError("title is required")
 	}
 
+	if req.ParentID != nil && *req.ParentID == "" {
+		req.ParentID = nil
+	}
+
 	// Create task domain model
 	now := time.Now()
 	task := &domain.Task{
 		ID:          uuid.New().String(),
 		Title:       req.Title,
 		Description: req.Description,
-		Status:      "pending",
+		Status:      domain.TaskStatusPending,
 		Priority:    req.Priority,
 		UserID:      userID,
 		ProjectID:   req.ProjectID,
-		DueDate:     nil, // Parse due_date if provided
+		ParentID:    req.ParentID,
 		CreatedAt:   now,
 		UpdatedAt:   now,
+		Version:     1,
 	}
 
 	// Parse due date if provided
-	if req.DueDate != nil && *req.DueDate != "" {
-		dueDate, err := time.Parse(time.RFC3339, *req.DueDate)
+	due, err := parseDue(req.DueDate, req.DueDatetime, req.DueTimezone)
+	if err != nil {
+		return nil, err
+	}
+	task.SetDue(due)
+
+	if req.EstimatedMinutes != nil {
+		if err := task.SetEstimate(*req.EstimatedMinutes); err != nil {
+			return nil, apperrors.NewBadRequestError(err.Error())
+		}
+	}
+
+	// Parse recurrence if provided
+	if req.Recurrence != nil && *req.Recurrence != "" {
+		if err := task.SetRecurrence(*req.Recurrence, now); err != nil {
+			return nil, apperrors.NewBadRequestError(err.Error())
+		}
+	}
+
+	// Subtasks inherit the project of their parent
+	if task.ParentID != nil {
+		parent, err := repos.Tasks.GetByID(*task.ParentID)
 		if err != nil {
-			return nil, apperrors.NewBadRequestError("invalid due_date format, should be RFC3339")
+			return nil, apperrors.NewNotFoundError("parent task not found")
 		}
-		task.DueDate = &dueDate
+		if parent.UserID != userID {
+			return nil, apperrors.NewForbiddenError("access denied to parent task")
+		}
+		task.ProjectID = parent.ProjectID
+	}
+
+	// New tasks go to the end of their project or inbox
+	position, err := lastPosition(repos.Tasks, userID, task.ProjectID)
+	if err != nil {
+		return nil, err
 	}
+	task.Position = position
 
 	// Create task in repository
-	if err := uc.taskRepo.Create(task); err != nil {
+	if err := repos.Tasks.Create(task); err != nil {
 		return nil, apperrors.NewInternalError("failed to create task", err)
 	}
 
-	// Return response DTO
-	return mapper.ToTaskResponse(task), nil
+	// Attach labels, creating the ones the user does not have yet
+	if len(req.Labels) > 0 {
+		if err := setTaskLabels(repos.Labels, task, req.Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := recordTaskCreated(repos, task); err != nil {
+		return nil, err
+	}
+	return task, nil
 }
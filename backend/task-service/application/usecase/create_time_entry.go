@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type CreateTimeEntryUseCase struct {
+	taskRepo      domain.TaskRepository
+	timeEntryRepo domain.TimeEntryRepository
+}
+
+func NewCreateTimeEntryUseCase(taskRepo domain.TaskRepository, timeEntryRepo domain.TimeEntryRepository) *CreateTimeEntryUseCase {
+	return &CreateTimeEntryUseCase{
+		taskRepo:      taskRepo,
+		timeEntryRepo: timeEntryRepo,
+	}
+}
+
+// Execute records time worked on a task without a timer
+func (uc *CreateTimeEntryUseCase) Execute(ctx context.Context, taskID, userID string, req dto.CreateTimeEntryRequest) (*dto.TimeEntryResponse, error) {
+	task, err := getOwnedTask(uc.taskRepo, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	startedAt, err := time.Parse(time.RFC3339, req.StartedAt)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid started_at format, should be RFC3339")
+	}
+	stoppedAt, err := time.Parse(time.RFC3339, req.StoppedAt)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid stopped_at format, should be RFC3339")
+	}
+	if !stoppedAt.After(startedAt) {
+		return nil, apperrors.NewBadRequestError("stopped_at must be after started_at")
+	}
+
+	now := time.Now()
+	if stoppedAt.After(now) {
+		return nil, apperrors.NewBadRequestError("stopped_at cannot be in the future")
+	}
+
+	startedAt, stoppedAt = startedAt.UTC(), stoppedAt.UTC()
+	entry := &domain.TimeEntry{
+		ID:        uuid.New().String(),
+		TaskID:    task.ID,
+		UserID:    userID,
+		StartedAt: startedAt,
+		StoppedAt: &stoppedAt,
+		Note:      req.Note,
+		CreatedAt: now,
+	}
+	if err := uc.timeEntryRepo.Create(entry); err != nil {
+		return nil, apperrors.NewInternalError("failed to create time entry", err)
+	}
+
+	return mapper.ToTimeEntryResponse(entry, now), nil
+}
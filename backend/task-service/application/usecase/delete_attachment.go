@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type DeleteAttachmentUseCase struct {
+	taskRepo       domain.TaskRepository
+	attachmentRepo domain.AttachmentRepository
+	blobStore      domain.BlobStore
+}
+
+func NewDeleteAttachmentUseCase(taskRepo domain.TaskRepository, attachmentRepo domain.AttachmentRepository, blobStore domain.BlobStore) *DeleteAttachmentUseCase {
+	return &DeleteAttachmentUseCase{
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+	}
+}
+
+// Execute deletes an attachment. The blob goes first, so a failure leaves a
+// record that a retry can delete rather than an unreferenced blob.
+func (uc *DeleteAttachmentUseCase) Execute(ctx context.Context, taskID, attachmentID, userID string) error {
+	attachment, err := getTaskAttachment(uc.taskRepo, uc.attachmentRepo, taskID, attachmentID, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.blobStore.Delete(ctx, attachment.StorageKey); err != nil {
+		return apperrors.NewInternalError("failed to delete attachment content", err)
+	}
+
+	if err := uc.attachmentRepo.Delete(attachment.ID); err != nil {
+		return apperrors.NewInternalError("failed to delete attachment", err)
+	}
+
+	return nil
+}
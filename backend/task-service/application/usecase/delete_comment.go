@@ -0,0 +1,33 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type DeleteCommentUseCase struct {
+	taskRepo    domain.TaskRepository
+	commentRepo domain.CommentRepository
+}
+
+func NewDeleteCommentUseCase(taskRepo domain.TaskRepository, commentRepo domain.CommentRepository) *DeleteCommentUseCase {
+	return &DeleteCommentUseCase{
+		taskRepo:    taskRepo,
+		commentRepo: commentRepo,
+	}
+}
+
+func (uc *DeleteCommentUseCase) Execute(ctx context.Context, taskID, commentID, userID string) error {
+	if _, err := getTaskComment(uc.taskRepo, uc.commentRepo, taskID, commentID, userID); err != nil {
+		return err
+	}
+
+	if err := uc.commentRepo.Delete(commentID); err != nil {
+		return apperrors.NewInternalError("failed to delete comment", err)
+	}
+
+	return nil
+}
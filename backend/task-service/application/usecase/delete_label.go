@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type DeleteLabelUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewDeleteLabelUseCase(transactor domain.Transactor) *DeleteLabelUseCase {
+	return &DeleteLabelUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute deletes a label and detaches it from all tasks, recording an
+// update of each of them
+func (uc *DeleteLabelUseCase) Execute(ctx context.Context, labelID, userID string) error {
+	return uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		if _, err := getOwnedLabel(repos.Labels, labelID, userID); err != nil {
+			return err
+		}
+
+		return relabelTasks(repos, labelID, func() error {
+			if err := repos.Labels.Delete(labelID); err != nil {
+				return apperrors.NewInternalError("failed to delete label", err)
+			}
+			return nil
+		})
+	})
+}
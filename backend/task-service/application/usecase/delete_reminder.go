@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type DeleteReminderUseCase struct {
+	taskRepo     domain.TaskRepository
+	reminderRepo domain.ReminderRepository
+}
+
+func NewDeleteReminderUseCase(taskRepo domain.TaskRepository, reminderRepo domain.ReminderRepository) *DeleteReminderUseCase {
+	return &DeleteReminderUseCase{
+		taskRepo:     taskRepo,
+		reminderRepo: reminderRepo,
+	}
+}
+
+func (uc *DeleteReminderUseCase) Execute(ctx context.Context, taskID, reminderID, userID string) error {
+	if _, err := getOwnedTask(uc.taskRepo, taskID, userID); err != nil {
+		return err
+	}
+
+	if reminderID == "" {
+		return apperrors.NewBadRequestError("reminder ID is required")
+	}
+
+	reminder, err := uc.reminderRepo.GetByID(reminderID)
+	if err != nil || reminder.TaskID != taskID {
+		return apperrors.NewNotFoundError("reminder not found")
+	}
+
+	if err := uc.reminderRepo.Delete(reminderID); err != nil {
+		return apperrors.NewInternalError("failed to delete reminder", err)
+	}
+
+	return nil
+}
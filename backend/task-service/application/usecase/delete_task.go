@@ -2,22 +2,26 @@ package usecase
 
 import (
 	"context"
+
 	apperrors "github.com/todoist/backend/pkg/errors"
 
 	"github.com/todoist/backend/task-service/domain"
 )
 
 type DeleteTaskUseCase struct {
-	taskRepo domain.TaskRepository
+	transactor domain.Transactor
 }
 
-func NewDeleteTaskUseCase(taskRepo domain.TaskRepository) *DeleteTaskUseCase {
+func NewDeleteTaskUseCase(transactor domain.Transactor) *DeleteTaskUseCase {
 	return &DeleteTaskUseCase{
-		taskRepo: taskRepo,
+		transactor: transactor,
 	}
 }
 
-func (uc *DeleteTaskUseCase) Execute(ctx context.Context, taskID, userID string) error {
+// Execute moves a task with its subtree to the trash, from where it can be
+// restored until it is purged. A non-nil ifMatch must equal the task's
+// current version.
+func (uc *DeleteTaskUseCase) Execute(ctx context.Context, taskID, userID string, ifMatch *int) error {
 	// Validate inputs
 	if taskID == "" {
 		return apperrors.NewBadRequestError("task ID is required")
@@ -26,8 +30,15 @@ func (uc *DeleteTaskUseCase) Execute(ctx context.Context, taskID, userID string)
 		return apperrors.NewBadRequestError("user ID is required")
 	}
 
+	return uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		return uc.delete(repos, taskID, userID, ifMatch)
+	})
+}
+
+// delete trashes the subtree inside a transaction and records its events
+func (uc *DeleteTaskUseCase) delete(repos domain.Repositories, taskID, userID string, ifMatch *int) error {
 	// Get existing task
-	task, err := uc.taskRepo.GetByID(taskID)
+	task, err := repos.Tasks.GetByID(taskID)
 	if err != nil {
 		return apperrors.NewNotFoundError("task not found")
 	}
@@ -36,11 +47,30 @@ func (uc *DeleteTaskUseCase) Execute(ctx context.Context, taskID, userID string)
 	if task.UserID != userID {
 		return apperrors.NewForbiddenError("access denied to this task")
 	}
+	if err := checkTaskVersion(task, ifMatch); err != nil {
+		return err
+	}
+
+	// Subtasks are trashed with the task, so each gets its own event
+	subtree, err := repos.Tasks.GetSubtree(taskID)
+	if err != nil {
+		return apperrors.NewInternalError("failed to get subtasks", err)
+	}
 
-	// Delete task
-	if err := uc.taskRepo.Delete(taskID); err != nil {
+	// Move task to the trash
+	if err := repos.Tasks.Delete(taskID); err != nil {
+		return apperrors.NewInternalError("failed to delete task", err)
+	}
+	trashed, err := repos.Tasks.GetDeleted(taskID)
+	if err != nil {
 		return apperrors.NewInternalError("failed to delete task", err)
 	}
 
+	for _, deleted := range subtree {
+		if err := recordTaskDeleted(repos, deleted, *trashed.DeletedAt); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type DeleteTimeEntryUseCase struct {
+	taskRepo      domain.TaskRepository
+	timeEntryRepo domain.TimeEntryRepository
+}
+
+func NewDeleteTimeEntryUseCase(taskRepo domain.TaskRepository, timeEntryRepo domain.TimeEntryRepository) *DeleteTimeEntryUseCase {
+	return &DeleteTimeEntryUseCase{
+		taskRepo:      taskRepo,
+		timeEntryRepo: timeEntryRepo,
+	}
+}
+
+// Execute deletes a time entry; deleting a running timer discards it
+func (uc *DeleteTimeEntryUseCase) Execute(ctx context.Context, taskID, entryID, userID string) error {
+	if _, err := getOwnedTask(uc.taskRepo, taskID, userID); err != nil {
+		return err
+	}
+
+	if entryID == "" {
+		return apperrors.NewBadRequestError("time entry ID is required")
+	}
+
+	entry, err := uc.timeEntryRepo.GetByID(entryID)
+	if err != nil || entry.TaskID != taskID {
+		return apperrors.NewNotFoundError("time entry not found")
+	}
+
+	if err := uc.timeEntryRepo.Delete(entryID); err != nil {
+		return apperrors.NewInternalError("failed to delete time entry", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+	"github.com/todoist/backend/pkg/events"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const reminderBatchSize = 100
+
+type FireRemindersUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewFireRemindersUseCase(transactor domain.Transactor) *FireRemindersUseCase {
+	return &FireRemindersUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute fires the reminders that are due and returns how many fired.
+// Each reminder's TaskReminderDue goes to the outbox in the transaction
+// that unschedules it, so a reminder fires exactly once even with several
+// replicas running or a crash in between. Delivering the event is
+// at-least-once, though: consumers must drop a message whose MessageId,
+// the event ID, they have already handled, or a notification goes out
+// twice.
+func (uc *FireRemindersUseCase) Execute(ctx context.Context) (int, error) {
+	fired := 0
+	for ctx.Err() == nil {
+		var claimed int
+		err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+			var err error
+			claimed, err = fireReminderBatch(repos, time.Now())
+			return err
+		})
+		if err != nil {
+			return fired, err
+		}
+
+		fired += claimed
+		if claimed < reminderBatchSize {
+			break
+		}
+	}
+	return fired, nil
+}
+
+// fireReminderBatch fires one batch of due reminders inside a transaction
+// and returns its size
+func fireReminderBatch(repos domain.Repositories, now time.Time) (int, error) {
+	reminders, err := repos.Reminders.ClaimDue(now, reminderBatchSize)
+	if err != nil {
+		return 0, apperrors.NewInternalError("failed to claim reminders", err)
+	}
+
+	for _, reminder := range reminders {
+		task, err := repos.Tasks.GetByID(reminder.TaskID)
+		if err != nil {
+			return 0, apperrors.NewInternalError("failed to get task", err)
+		}
+
+		err = recordEvent(repos.Outbox, events.NewTaskReminderDue(
+			parseUUID(reminder.UserID), parseUUID(reminder.ID), parseUUID(task.ID), projectUUID(task),
+			task.Title, task.DueDate, *reminder.FireAt,
+		))
+		if err != nil {
+			return 0, err
+		}
+
+		if err := repos.Reminders.MarkFired(reminder.ID, now); err != nil {
+			return 0, apperrors.NewInternalError("failed to mark reminder fired", err)
+		}
+	}
+
+	return len(reminders), nil
+}
+
+// rescheduleReminders schedules the reminders of an open task after its due
+// date moved or it was reopened. Scheduling only ever picks moments still
+// ahead, so a reminder that already fired for a due date, or came due while
+// the task was completed, never fires for it again.
+func rescheduleReminders(repos domain.Repositories, task *domain.Task, now time.Time) error {
+	reminders, err := repos.Reminders.GetByTaskID(task.ID)
+	if err != nil {
+		return apperrors.NewInternalError("failed to get reminders", err)
+	}
+
+	for _, reminder := range reminders {
+		reminder.Schedule(task, now)
+	}
+
+	if err := repos.Reminders.Reschedule(reminders); err != nil {
+		return apperrors.NewInternalError("failed to reschedule reminders", err)
+	}
+	return nil
+}
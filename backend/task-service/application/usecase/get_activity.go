@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const (
+	defaultActivityPageSize = 50
+	maxActivityPageSize     = 200
+)
+
+type GetActivityUseCase struct {
+	taskRepo     domain.TaskRepository
+	activityRepo domain.ActivityRepository
+}
+
+func NewGetActivityUseCase(taskRepo domain.TaskRepository, activityRepo domain.ActivityRepository) *GetActivityUseCase {
+	return &GetActivityUseCase{
+		taskRepo:     taskRepo,
+		activityRepo: activityRepo,
+	}
+}
+
+// Execute lists a page of the user's activity, newest first. With a task ID
+// only that task's history is listed; trashed tasks keep their history.
+func (uc *GetActivityUseCase) Execute(ctx context.Context, userID string, req dto.ListActivityRequest) (*dto.ActivityListResponse, error) {
+	// Validate input
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	query := domain.ActivityQuery{}
+	if req.TaskID != "" {
+		if err := uc.checkTaskAccess(req.TaskID, userID); err != nil {
+			return nil, err
+		}
+		query.TaskID = &req.TaskID
+	}
+
+	limit, err := pageSize(req.Limit, defaultActivityPageSize, maxActivityPageSize)
+	if err != nil {
+		return nil, err
+	}
+	if req.Cursor != "" {
+		query.After, err = decodeActivityCursor(req.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Fetch one entry beyond the page to learn whether another page exists
+	query.Limit = limit + 1
+	activities, err := uc.activityRepo.Find(userID, query)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get activity", err)
+	}
+
+	response := &dto.ActivityListResponse{Data: []*dto.ActivityResponse{}}
+	if len(activities) > limit {
+		activities = activities[:limit]
+		next := encodeActivityCursor(activities[len(activities)-1])
+		response.NextCursor = &next
+		response.HasMore = true
+	}
+
+	// Convert to response DTOs
+	for _, activity := range activities {
+		response.Data = append(response.Data, mapper.ToActivityResponse(activity))
+	}
+
+	return response, nil
+}
+
+// checkTaskAccess verifies that the task, live or trashed, belongs to the user
+func (uc *GetActivityUseCase) checkTaskAccess(taskID, userID string) error {
+	task, err := uc.taskRepo.GetByID(taskID)
+	if err != nil {
+		task, err = uc.taskRepo.GetDeleted(taskID)
+	}
+	if err != nil {
+		return apperrors.NewNotFoundError("task not found")
+	}
+
+	if task.UserID != userID {
+		return apperrors.NewForbiddenError("access denied to this task")
+	}
+	return nil
+}
+
+// activityCursorPayload is the wire form of a domain.ActivityCursor
+type activityCursorPayload struct {
+	CreatedAt time.Time `json:"c"`
+	ID        string    `json:"id"`
+}
+
+func encodeActivityCursor(activity *domain.Activity) string {
+	data, _ := json.Marshal(activityCursorPayload{CreatedAt: activity.CreatedAt, ID: activity.ID})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeActivityCursor(encoded string) (*domain.ActivityCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid cursor")
+	}
+	var payload activityCursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == "" {
+		return nil, apperrors.NewBadRequestError("invalid cursor")
+	}
+
+	cursor := domain.ActivityCursor(payload)
+	return &cursor, nil
+}
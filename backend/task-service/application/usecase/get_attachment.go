@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetAttachmentUseCase struct {
+	taskRepo       domain.TaskRepository
+	attachmentRepo domain.AttachmentRepository
+	blobStore      domain.BlobStore
+	urlExpiry      time.Duration
+}
+
+func NewGetAttachmentUseCase(
+	taskRepo domain.TaskRepository,
+	attachmentRepo domain.AttachmentRepository,
+	blobStore domain.BlobStore,
+	urlExpiry time.Duration,
+) *GetAttachmentUseCase {
+	return &GetAttachmentUseCase{
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+		urlExpiry:      urlExpiry,
+	}
+}
+
+// Execute returns an attachment with a fresh download URL
+func (uc *GetAttachmentUseCase) Execute(ctx context.Context, taskID, attachmentID, userID string) (*dto.AttachmentResponse, error) {
+	attachment, err := getTaskAttachment(uc.taskRepo, uc.attachmentRepo, taskID, attachmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAttachmentResponse(ctx, uc.blobStore, attachment, uc.urlExpiry)
+}
@@ -0,0 +1,272 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/ical"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// Calendar components a feed can hold
+const (
+	CalendarComponentsTodo  = "todo"
+	CalendarComponentsEvent = "event"
+	CalendarComponentsBoth  = "both"
+)
+
+// defaultEventMinutes is the length of the event of a timed task without
+// an estimate
+const defaultEventMinutes = 30
+
+// calendarUIDDomain makes the UIDs of feed entries globally unique
+const calendarUIDDomain = "tasks.todoist"
+
+// untilTimePattern matches the time part of an RRULE's UNTIL, which a rule
+// starting on a date must not have
+var untilTimePattern = regexp.MustCompile(`(UNTIL=\d{8})T\d{6}Z`)
+
+// icalPriorities maps task priorities to iCalendar's, where 1 is the
+// highest and 9 the lowest; the lowest task priority is left undefined
+var icalPriorities = map[int]string{4: "1", 3: "5", 2: "9"}
+
+type GetCalendarFeedUseCase struct {
+	feedRepo domain.CalendarFeedRepository
+	taskRepo domain.TaskRepository
+}
+
+func NewGetCalendarFeedUseCase(feedRepo domain.CalendarFeedRepository, taskRepo domain.TaskRepository) *GetCalendarFeedUseCase {
+	return &GetCalendarFeedUseCase{
+		feedRepo: feedRepo,
+		taskRepo: taskRepo,
+	}
+}
+
+// Execute renders the open tasks with a due date of the token's owner as an
+// iCalendar feed. Timed tasks keep their time zone, so a recurring one stays
+// at the same wall clock time across daylight saving changes.
+func (uc *GetCalendarFeedUseCase) Execute(ctx context.Context, req dto.CalendarFeedRequest) ([]byte, error) {
+	// Authenticate with the feed token
+	if req.Token == "" {
+		return nil, apperrors.NewUnauthorizedError("token is required")
+	}
+	feed, err := uc.feedRepo.GetByTokenHash(domain.HashFeedToken(req.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NewUnauthorizedError("invalid token")
+	}
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get calendar feed", err)
+	}
+
+	todos, events := true, true
+	switch req.Components {
+	case "", CalendarComponentsBoth:
+	case CalendarComponentsTodo:
+		events = false
+	case CalendarComponentsEvent:
+		todos = false
+	default:
+		return nil, apperrors.NewBadRequestError("components must be todo, event or both")
+	}
+
+	query := domain.TaskQuery{
+		Status:    domain.TaskStatusPending,
+		ProjectID: req.ProjectID,
+		Labels:    req.Labels,
+		Sort:      domain.TaskSortDueDate,
+	}
+	tasks, err := uc.taskRepo.Find(feed.UserID, query)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get tasks", err)
+	}
+
+	var due []*domain.Task
+	for _, task := range tasks {
+		if task.DueDate != nil {
+			due = append(due, task)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := writeCalendar(&buf, due, todos, events, time.Now()); err != nil {
+		return nil, apperrors.NewInternalError("failed to render calendar", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// writeCalendar writes tasks, which all have a due date, as VTODOs, VEVENTs
+// or both, after the definitions of the time zones they use
+func writeCalendar(out io.Writer, tasks []*domain.Task, todos, events bool, now time.Time) error {
+	w := ical.NewWriter(out)
+	w.Begin("VCALENDAR")
+	w.Property("VERSION", "2.0")
+	w.Property("PRODID", "-//Todoist//Tasks//EN")
+	w.Property("CALSCALE", "GREGORIAN")
+	w.Property("METHOD", "PUBLISH")
+	w.Text("X-WR-CALNAME", "Tasks")
+
+	zones := make(map[string]*time.Location)
+	for _, task := range tasks {
+		if loc := calendarLocation(task); loc != time.UTC {
+			zones[loc.String()] = loc
+		}
+	}
+	names := make([]string, 0, len(zones))
+	for name := range zones {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		w.Timezone(zones[name], now.Year())
+	}
+
+	for _, task := range tasks {
+		if todos {
+			writeTodo(w, task)
+		}
+		if events {
+			writeEvent(w, task)
+		}
+	}
+
+	w.End("VCALENDAR")
+	return w.Flush()
+}
+
+// writeTodo writes a task as a to-do due at its due date
+func writeTodo(w *ical.Writer, task *domain.Task) {
+	w.Begin("VTODO")
+	w.Property("UID", task.ID+"@"+calendarUIDDomain)
+	writeTaskProperties(w, task)
+	writeTodoDates(w, task)
+	writeRecurrence(w, task)
+	w.Property("STATUS", "NEEDS-ACTION")
+	w.End("VTODO")
+}
+
+// writeTodoDates writes when a to-do is due and, when it recurs, its
+// DTSTART. An RRULE needs a DTSTART, which RFC 5545 requires to come before
+// DUE and which has to stay on a day the rule picks, so it is the start of
+// the due day. A recurring all-day to-do is therefore due at the end of its
+// day, and a recurring timed one due at the very start of its day is given
+// a zero DURATION instead of a DUE.
+func writeTodoDates(w *ical.Writer, task *domain.Task) {
+	if !task.IsRecurring() {
+		value, params := calendarDue(task)
+		w.Property("DUE", value, params...)
+		return
+	}
+
+	if task.DueAllDay {
+		day := task.DueDate.UTC()
+		w.Property("DTSTART", ical.Date(day), "VALUE=DATE")
+		w.Property("DUE", ical.Date(day.AddDate(0, 0, 1)), "VALUE=DATE")
+		return
+	}
+
+	due := task.DueDate.In(calendarLocation(task))
+	start := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, due.Location())
+	value, params := calendarTime(task, start)
+	w.Property("DTSTART", value, params...)
+	if !start.Before(due) {
+		w.Property("DURATION", "PT0S")
+		return
+	}
+	value, params = calendarDue(task)
+	w.Property("DUE", value, params...)
+}
+
+// writeEvent writes a task as an event at its due date: the whole day for an
+// all-day task, otherwise as long as its estimate. Events are transparent,
+// tasks do not make the user busy.
+func writeEvent(w *ical.Writer, task *domain.Task) {
+	w.Begin("VEVENT")
+	w.Property("UID", task.ID+"-event@"+calendarUIDDomain)
+	writeTaskProperties(w, task)
+
+	value, params := calendarDue(task)
+	w.Property("DTSTART", value, params...)
+	if task.DueAllDay {
+		w.Property("DTEND", ical.Date(task.DueDate.UTC().AddDate(0, 0, 1)), "VALUE=DATE")
+	} else {
+		minutes := defaultEventMinutes
+		if task.EstimatedMinutes != nil {
+			minutes = *task.EstimatedMinutes
+		}
+		w.Property("DURATION", fmt.Sprintf("PT%dM", minutes))
+	}
+	writeRecurrence(w, task)
+	w.Property("TRANSP", "TRANSPARENT")
+	w.End("VEVENT")
+}
+
+// writeTaskProperties writes the properties to-dos and events share
+func writeTaskProperties(w *ical.Writer, task *domain.Task) {
+	w.Property("DTSTAMP", ical.UTC(task.UpdatedAt))
+	w.Property("CREATED", ical.UTC(task.CreatedAt))
+	w.Property("LAST-MODIFIED", ical.UTC(task.UpdatedAt))
+	w.Property("SEQUENCE", fmt.Sprint(task.Version-1))
+	w.Text("SUMMARY", task.Title)
+	if task.Description != "" {
+		w.Text("DESCRIPTION", task.Description)
+	}
+	if priority, ok := icalPriorities[task.Priority]; ok {
+		w.Property("PRIORITY", priority)
+	}
+	if len(task.Labels) > 0 {
+		labels := make([]string, len(task.Labels))
+		for i, label := range task.Labels {
+			labels[i] = ical.EscapeText(label)
+		}
+		w.Property("CATEGORIES", strings.Join(labels, ","))
+	}
+}
+
+func writeRecurrence(w *ical.Writer, task *domain.Task) {
+	if !task.IsRecurring() {
+		return
+	}
+	rule := task.RecurrenceRule
+	if task.DueAllDay {
+		rule = untilTimePattern.ReplaceAllString(rule, "$1")
+	}
+	w.Property("RRULE", rule)
+}
+
+// calendarDue formats the due date with its parameters: a date for an
+// all-day task, a local time in the task's time zone or a UTC time
+func calendarDue(task *domain.Task) (string, []string) {
+	if task.DueAllDay {
+		return ical.Date(task.DueDate.UTC()), []string{"VALUE=DATE"}
+	}
+	return calendarTime(task, *task.DueDate)
+}
+
+// calendarTime formats a moment of a timed task the way its due date is
+func calendarTime(task *domain.Task, t time.Time) (string, []string) {
+	loc := calendarLocation(task)
+	if loc == time.UTC {
+		return ical.UTC(t), nil
+	}
+	return ical.Local(t.In(loc)), []string{"TZID=" + loc.String()}
+}
+
+// calendarLocation is the zone a task's due date is written in, UTC for
+// all-day tasks and due dates without a zone
+func calendarLocation(task *domain.Task) *time.Location {
+	if task.DueDate == nil {
+		return time.UTC
+	}
+	return task.DueLocation()
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetCompletedTasksUseCase struct {
+	taskRepo domain.TaskRepository
+}
+
+func NewGetCompletedTasksUseCase(taskRepo domain.TaskRepository) *GetCompletedTasksUseCase {
+	return &GetCompletedTasksUseCase{
+		taskRepo: taskRepo,
+	}
+}
+
+// Execute lists a page of the user's completed tasks, most recently
+// completed first. Occurrences of recurring tasks that moved on to their
+// next due date are in the task's completion history instead.
+func (uc *GetCompletedTasksUseCase) Execute(ctx context.Context, userID string, req dto.CompletedTasksRequest) (*dto.TaskListResponse, error) {
+	// Validate input
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	query, err := buildCompletedQuery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one task beyond the page to learn whether another page exists
+	pageSize := query.Limit
+	query.Limit++
+	tasks, err := uc.taskRepo.Find(userID, query)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get completed tasks", err)
+	}
+
+	response := &dto.TaskListResponse{Data: []*dto.TaskResponse{}}
+	if len(tasks) > pageSize {
+		tasks = tasks[:pageSize]
+		next := encodeCursor(domain.CursorAfter(tasks[len(tasks)-1], query.Sort, query.Descending))
+		response.NextCursor = &next
+		response.HasMore = true
+	}
+
+	// Convert to response DTOs
+	for _, task := range tasks {
+		response.Data = append(response.Data, mapper.ToTaskResponse(task))
+	}
+	response.Total = len(response.Data)
+
+	return response, nil
+}
+
+// buildCompletedQuery turns the request into a repository query over the
+// completion times of the range's days
+func buildCompletedQuery(req dto.CompletedTasksRequest) (domain.TaskQuery, error) {
+	query := domain.TaskQuery{
+		Status:     domain.TaskStatusCompleted,
+		ProjectID:  req.ProjectID,
+		Sort:       domain.TaskSortCompletedAt,
+		Descending: true,
+	}
+
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return query, apperrors.NewBadRequestError("invalid timezone")
+	}
+
+	if req.From != "" {
+		firstDay, err := time.ParseInLocation(domain.DueDateLayout, req.From, loc)
+		if err != nil {
+			return query, apperrors.NewBadRequestError("invalid from date, should be YYYY-MM-DD")
+		}
+		query.CompletedFrom = &firstDay
+	}
+	if req.To != "" {
+		lastDay, err := time.ParseInLocation(domain.DueDateLayout, req.To, loc)
+		if err != nil {
+			return query, apperrors.NewBadRequestError("invalid to date, should be YYYY-MM-DD")
+		}
+		// The range ends at the start of the day after the last one
+		end := lastDay.AddDate(0, 0, 1)
+		query.CompletedTo = &end
+	}
+	if query.CompletedFrom != nil && query.CompletedTo != nil && !query.CompletedFrom.Before(*query.CompletedTo) {
+		return query, apperrors.NewBadRequestError("from must not be after to")
+	}
+
+	query.Limit, err = pageSize(req.Limit, defaultTaskPageSize, maxTaskPageSize)
+	if err != nil {
+		return query, err
+	}
+
+	if req.Cursor != "" {
+		query.After, err = decodeCursor(req.Cursor, query.Sort, query.Descending)
+		if err != nil {
+			return query, err
+		}
+	}
+
+	return query, nil
+}
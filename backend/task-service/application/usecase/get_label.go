@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetLabelUseCase struct {
+	labelRepo domain.LabelRepository
+}
+
+func NewGetLabelUseCase(labelRepo domain.LabelRepository) *GetLabelUseCase {
+	return &GetLabelUseCase{
+		labelRepo: labelRepo,
+	}
+}
+
+func (uc *GetLabelUseCase) Execute(ctx context.Context, labelID, userID string) (*dto.LabelResponse, error) {
+	label, err := getOwnedLabel(uc.labelRepo, labelID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToLabelResponse(label), nil
+}
+
+// getOwnedLabel loads a label and verifies that it belongs to the user
+func getOwnedLabel(labelRepo domain.LabelRepository, labelID, userID string) (*domain.Label, error) {
+	if labelID == "" {
+		return nil, apperrors.NewBadRequestError("label ID is required")
+	}
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	label, err := labelRepo.GetByID(labelID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("label not found")
+	}
+
+	if label.UserID != userID {
+		return nil, apperrors.NewForbiddenError("access denied to this label")
+	}
+
+	return label, nil
+}
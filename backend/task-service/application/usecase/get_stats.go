@@ -0,0 +1,115 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const (
+	defaultStatsDays  = 7
+	maxStatsDays      = 90
+	defaultStatsWeeks = 4
+	maxStatsWeeks     = 52
+)
+
+type GetStatsUseCase struct {
+	statsRepo domain.StatsRepository
+}
+
+func NewGetStatsUseCase(statsRepo domain.StatsRepository) *GetStatsUseCase {
+	return &GetStatsUseCase{
+		statsRepo: statsRepo,
+	}
+}
+
+// Execute returns the user's productivity from the aggregates kept up to
+// date by completions. Days and streaks are in the user's time zone, and
+// streaks are measured against the current goals.
+func (uc *GetStatsUseCase) Execute(ctx context.Context, userID string, req dto.StatsRequest) (*dto.StatsResponse, error) {
+	// Validate inputs
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+	days, err := statsRange(req.Days, defaultStatsDays, maxStatsDays, "days")
+	if err != nil {
+		return nil, err
+	}
+	weeks, err := statsRange(req.Weeks, defaultStatsWeeks, maxStatsWeeks, "weeks")
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	stats, err := uc.statsRepo.Get(userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		stats = domain.NewUserStats(userID, now)
+	} else if err != nil {
+		return nil, apperrors.NewInternalError("failed to get stats", err)
+	}
+	return buildStatsResponse(uc.statsRepo, stats, now, days, weeks)
+}
+
+// buildStatsResponse reads the daily counts of stats' user, whose whole
+// history the streaks need
+func buildStatsResponse(statsRepo domain.StatsRepository, stats *domain.UserStats, now time.Time, days, weeks int) (*dto.StatsResponse, error) {
+	today := domain.StatsDay(now, stats.Location())
+	history, err := statsRepo.GetDays(stats.UserID, time.Time{}, today)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get stats", err)
+	}
+
+	daily := make(map[time.Time]int, len(history))
+	weekly := make(map[time.Time]int)
+	for _, day := range history {
+		daily[day.Day] = day.Completed
+		weekly[domain.StatsWeek(day.Day)] += day.Completed
+	}
+
+	response := &dto.StatsResponse{
+		Karma:          stats.Karma,
+		CompletedCount: stats.CompletedCount,
+		DailyGoal:      stats.DailyGoal,
+		WeeklyGoal:     stats.WeeklyGoal,
+		Timezone:       stats.Timezone,
+		Days:           make([]*dto.StatsPeriodResponse, 0, days),
+		Weeks:          make([]*dto.StatsPeriodResponse, 0, weeks),
+	}
+	for i := 0; i < days; i++ {
+		day := today.AddDate(0, 0, -i)
+		response.Days = append(response.Days, mapper.ToStatsPeriodResponse(day, daily[day], stats.DailyGoal))
+	}
+	thisWeek := domain.StatsWeek(today)
+	for i := 0; i < weeks; i++ {
+		week := thisWeek.AddDate(0, 0, -7*i)
+		response.Weeks = append(response.Weeks, mapper.ToStatsPeriodResponse(week, weekly[week], stats.WeeklyGoal))
+	}
+	response.Today = response.Days[0]
+	response.ThisWeek = response.Weeks[0]
+
+	response.DailyStreak = mapper.ToStreaksResponse(domain.DailyStreaks(history, stats.DailyGoal, today))
+	response.WeeklyStreak = mapper.ToStreaksResponse(domain.WeeklyStreaks(history, stats.WeeklyGoal, today))
+	return response, nil
+}
+
+// statsRange validates how many days or weeks to list, applying the
+// default when none was given and capping it at max
+func statsRange(requested, defaultSize, max int, name string) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, apperrors.NewBadRequestError(fmt.Sprintf("%s must be positive", name))
+	case requested == 0:
+		return defaultSize, nil
+	case requested > max:
+		return max, nil
+	}
+	return requested, nil
+}
@@ -0,0 +1,56 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetTaskAttachmentsUseCase struct {
+	taskRepo       domain.TaskRepository
+	attachmentRepo domain.AttachmentRepository
+	blobStore      domain.BlobStore
+	urlExpiry      time.Duration
+}
+
+func NewGetTaskAttachmentsUseCase(
+	taskRepo domain.TaskRepository,
+	attachmentRepo domain.AttachmentRepository,
+	blobStore domain.BlobStore,
+	urlExpiry time.Duration,
+) *GetTaskAttachmentsUseCase {
+	return &GetTaskAttachmentsUseCase{
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+		urlExpiry:      urlExpiry,
+	}
+}
+
+// Execute lists the attachments of a task and its comments, oldest first,
+// each with a fresh download URL
+func (uc *GetTaskAttachmentsUseCase) Execute(ctx context.Context, taskID, userID string) ([]*dto.AttachmentResponse, error) {
+	if _, err := getOwnedTask(uc.taskRepo, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := uc.attachmentRepo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get attachments", err)
+	}
+
+	responses := []*dto.AttachmentResponse{}
+	for _, attachment := range attachments {
+		response, err := toAttachmentResponse(ctx, uc.blobStore, attachment, uc.urlExpiry)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetTaskCommentsUseCase struct {
+	taskRepo    domain.TaskRepository
+	commentRepo domain.CommentRepository
+}
+
+func NewGetTaskCommentsUseCase(taskRepo domain.TaskRepository, commentRepo domain.CommentRepository) *GetTaskCommentsUseCase {
+	return &GetTaskCommentsUseCase{
+		taskRepo:    taskRepo,
+		commentRepo: commentRepo,
+	}
+}
+
+// Execute lists the comments of a task, oldest first
+func (uc *GetTaskCommentsUseCase) Execute(ctx context.Context, taskID, userID string) ([]*dto.CommentResponse, error) {
+	if _, err := getOwnedTask(uc.taskRepo, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	comments, err := uc.commentRepo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get comments", err)
+	}
+
+	responses := []*dto.CommentResponse{}
+	for _, comment := range comments {
+		responses = append(responses, mapper.ToCommentResponse(comment))
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,57 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetTaskCompletionsUseCase struct {
+	taskRepo       domain.TaskRepository
+	completionRepo domain.TaskCompletionRepository
+}
+
+func NewGetTaskCompletionsUseCase(taskRepo domain.TaskRepository, completionRepo domain.TaskCompletionRepository) *GetTaskCompletionsUseCase {
+	return &GetTaskCompletionsUseCase{
+		taskRepo:       taskRepo,
+		completionRepo: completionRepo,
+	}
+}
+
+// Execute returns the completion history of a task, most recent first
+func (uc *GetTaskCompletionsUseCase) Execute(ctx context.Context, taskID, userID string) ([]*dto.TaskCompletionResponse, error) {
+	// Validate inputs
+	if taskID == "" {
+		return nil, apperrors.NewBadRequestError("task ID is required")
+	}
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	// Get task from repository
+	task, err := uc.taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("task not found")
+	}
+
+	// Verify the task belongs to the user
+	if task.UserID != userID {
+		return nil, apperrors.NewForbiddenError("access denied to this task")
+	}
+
+	completions, err := uc.completionRepo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get task completions", err)
+	}
+
+	responses := []*dto.TaskCompletionResponse{}
+	for _, completion := range completions {
+		responses = append(responses, mapper.ToTaskCompletionResponse(completion))
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetTaskDependenciesUseCase struct {
+	taskRepo       domain.TaskRepository
+	dependencyRepo domain.DependencyRepository
+}
+
+func NewGetTaskDependenciesUseCase(taskRepo domain.TaskRepository, dependencyRepo domain.DependencyRepository) *GetTaskDependenciesUseCase {
+	return &GetTaskDependenciesUseCase{
+		taskRepo:       taskRepo,
+		dependencyRepo: dependencyRepo,
+	}
+}
+
+// Execute lists the tasks a task is blocked by and the tasks it blocks,
+// oldest dependency first
+func (uc *GetTaskDependenciesUseCase) Execute(ctx context.Context, taskID, userID string) (*dto.TaskDependenciesResponse, error) {
+	if _, err := getOwnedTask(uc.taskRepo, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	blockerIDs, err := uc.dependencyRepo.GetBlockerIDs(taskID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get dependencies", err)
+	}
+	blockedIDs, err := uc.dependencyRepo.GetBlockedIDs(taskID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get dependencies", err)
+	}
+
+	return &dto.TaskDependenciesResponse{
+		BlockedBy: uc.loadTasks(blockerIDs),
+		Blocking:  uc.loadTasks(blockedIDs),
+	}, nil
+}
+
+// loadTasks maps the tasks that are not in the trash
+func (uc *GetTaskDependenciesUseCase) loadTasks(ids []string) []*dto.TaskResponse {
+	responses := []*dto.TaskResponse{}
+	for _, id := range ids {
+		task, err := uc.taskRepo.GetByID(id)
+		if err != nil {
+			continue
+		}
+		responses = append(responses, mapper.ToTaskResponse(task))
+	}
+	return responses
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetTaskRemindersUseCase struct {
+	taskRepo     domain.TaskRepository
+	reminderRepo domain.ReminderRepository
+}
+
+func NewGetTaskRemindersUseCase(taskRepo domain.TaskRepository, reminderRepo domain.ReminderRepository) *GetTaskRemindersUseCase {
+	return &GetTaskRemindersUseCase{
+		taskRepo:     taskRepo,
+		reminderRepo: reminderRepo,
+	}
+}
+
+// Execute lists the reminders of a task, oldest first
+func (uc *GetTaskRemindersUseCase) Execute(ctx context.Context, taskID, userID string) ([]*dto.ReminderResponse, error) {
+	if _, err := getOwnedTask(uc.taskRepo, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	reminders, err := uc.reminderRepo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get reminders", err)
+	}
+
+	responses := []*dto.ReminderResponse{}
+	for _, reminder := range reminders {
+		responses = append(responses, mapper.ToReminderResponse(reminder))
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,48 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetTaskTimeEntriesUseCase struct {
+	taskRepo      domain.TaskRepository
+	timeEntryRepo domain.TimeEntryRepository
+}
+
+func NewGetTaskTimeEntriesUseCase(taskRepo domain.TaskRepository, timeEntryRepo domain.TimeEntryRepository) *GetTaskTimeEntriesUseCase {
+	return &GetTaskTimeEntriesUseCase{
+		taskRepo:      taskRepo,
+		timeEntryRepo: timeEntryRepo,
+	}
+}
+
+// Execute lists the time tracked on a task, oldest first, along with its
+// total in seconds
+func (uc *GetTaskTimeEntriesUseCase) Execute(ctx context.Context, taskID, userID string) ([]*dto.TimeEntryResponse, int64, error) {
+	if _, err := getOwnedTask(uc.taskRepo, taskID, userID); err != nil {
+		return nil, 0, err
+	}
+
+	entries, err := uc.timeEntryRepo.GetByTaskID(taskID)
+	if err != nil {
+		return nil, 0, apperrors.NewInternalError("failed to get time entries", err)
+	}
+
+	now := time.Now()
+	var total int64
+	responses := []*dto.TimeEntryResponse{}
+	for _, entry := range entries {
+		response := mapper.ToTimeEntryResponse(entry, now)
+		total += response.DurationSeconds
+		responses = append(responses, response)
+	}
+
+	return responses, total, nil
+}
@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetTaskTreeUseCase struct {
+	taskRepo domain.TaskRepository
+}
+
+func NewGetTaskTreeUseCase(taskRepo domain.TaskRepository) *GetTaskTreeUseCase {
+	return &GetTaskTreeUseCase{
+		taskRepo: taskRepo,
+	}
+}
+
+// Execute returns a task with all of its descendants nested as a tree
+func (uc *GetTaskTreeUseCase) Execute(ctx context.Context, taskID, userID string) (*dto.TaskTreeResponse, error) {
+	// Validate inputs
+	if taskID == "" {
+		return nil, apperrors.NewBadRequestError("task ID is required")
+	}
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	subtree, err := uc.taskRepo.GetSubtree(taskID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get subtasks", err)
+	}
+	if len(subtree) == 0 {
+		return nil, apperrors.NewNotFoundError("task not found")
+	}
+
+	// Verify the task belongs to the user
+	if subtree[0].UserID != userID {
+		return nil, apperrors.NewForbiddenError("access denied to this task")
+	}
+
+	return mapper.ToTaskTreeResponse(subtree), nil
+}
@@ -0,0 +1,93 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const (
+	// defaultTimeReportDays is the range of a report without dates
+	defaultTimeReportDays = 7
+	// maxTimeReportDays bounds the range of a report
+	maxTimeReportDays = 366
+)
+
+type GetTimeReportUseCase struct {
+	timeEntryRepo domain.TimeEntryRepository
+}
+
+func NewGetTimeReportUseCase(timeEntryRepo domain.TimeEntryRepository) *GetTimeReportUseCase {
+	return &GetTimeReportUseCase{
+		timeEntryRepo: timeEntryRepo,
+	}
+}
+
+// Execute totals the user's tracked time per project, label or day over a
+// range of days, including time on tasks that are in the trash
+func (uc *GetTimeReportUseCase) Execute(ctx context.Context, userID string, req dto.TimeReportRequest) (*dto.TimeReportResponse, error) {
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	if req.GroupBy == "" {
+		req.GroupBy = domain.TimeReportByProject
+	}
+	switch req.GroupBy {
+	case domain.TimeReportByProject, domain.TimeReportByLabel, domain.TimeReportByDay:
+	default:
+		return nil, apperrors.NewBadRequestError("group_by must be project, label or day")
+	}
+
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid timezone")
+	}
+	if req.Timezone == "" {
+		req.Timezone = "UTC"
+	}
+
+	now := time.Now().In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	lastDay, err := parseReportDay(req.To, today, loc)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid to date, should be YYYY-MM-DD")
+	}
+	firstDay, err := parseReportDay(req.From, lastDay.AddDate(0, 0, 1-defaultTimeReportDays), loc)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid from date, should be YYYY-MM-DD")
+	}
+	if lastDay.Before(firstDay) {
+		return nil, apperrors.NewBadRequestError("from must not be after to")
+	}
+	if firstDay.AddDate(0, 0, maxTimeReportDays).Before(lastDay.AddDate(0, 0, 1)) {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("a report covers at most %d days", maxTimeReportDays))
+	}
+	req.From = firstDay.Format(domain.DueDateLayout)
+	req.To = lastDay.Format(domain.DueDateLayout)
+
+	// The range ends at the start of the day after the last one
+	from, to := firstDay, lastDay.AddDate(0, 0, 1)
+	tracked, err := uc.timeEntryRepo.GetTracked(userID, from, to)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get tracked time", err)
+	}
+
+	total := domain.TotalTrackedTime(tracked, from, to, now)
+	groups := domain.SummarizeTrackedTime(tracked, req.GroupBy, from, to, now)
+	return mapper.ToTimeReportResponse(req, total, groups), nil
+}
+
+// parseReportDay reads a date in loc, falling back to def when empty
+func parseReportDay(value string, def time.Time, loc *time.Location) (time.Time, error) {
+	if value == "" {
+		return def, nil
+	}
+	return time.ParseInLocation(domain.DueDateLayout, value, loc)
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetTrashUseCase struct {
+	taskRepo domain.TaskRepository
+}
+
+func NewGetTrashUseCase(taskRepo domain.TaskRepository) *GetTrashUseCase {
+	return &GetTrashUseCase{
+		taskRepo: taskRepo,
+	}
+}
+
+// Execute lists the user's trashed tasks, most recently deleted first.
+// Subtasks deleted together with their parent are not listed separately;
+// restoring the parent restores them.
+func (uc *GetTrashUseCase) Execute(ctx context.Context, userID string) (*dto.TaskListResponse, error) {
+	// Validate input
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	tasks, err := uc.taskRepo.GetTrash(userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get trash", err)
+	}
+
+	// Convert to response DTOs
+	response := &dto.TaskListResponse{Data: make([]*dto.TaskResponse, 0, len(tasks))}
+	for _, task := range tasks {
+		response.Data = append(response.Data, mapper.ToTaskResponse(task))
+	}
+	response.Total = len(response.Data)
+
+	return response, nil
+}
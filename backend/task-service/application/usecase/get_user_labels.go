@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type GetUserLabelsUseCase struct {
+	labelRepo domain.LabelRepository
+}
+
+func NewGetUserLabelsUseCase(labelRepo domain.LabelRepository) *GetUserLabelsUseCase {
+	return &GetUserLabelsUseCase{
+		labelRepo: labelRepo,
+	}
+}
+
+func (uc *GetUserLabelsUseCase) Execute(ctx context.Context, userID string) ([]*dto.LabelResponse, error) {
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	labels, err := uc.labelRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get labels", err)
+	}
+
+	responses := []*dto.LabelResponse{}
+	for _, label := range labels {
+		responses = append(responses, mapper.ToLabelResponse(label))
+	}
+
+	return responses, nil
+}
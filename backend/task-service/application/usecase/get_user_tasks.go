@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"strings"
+
 	apperrors "github.com/todoist/backend/pkg/errors"
 
 	"github.com/todoist/backend/task-service/application/dto"
@@ -9,56 +11,96 @@ import (
 	"github.com/todoist/backend/task-service/domain"
 )
 
+const (
+	defaultTaskPageSize = 100
+	maxTaskPageSize     = 500
+)
+
 type GetUserTasksUseCase struct {
-	taskRepo domain.TaskRepository
+	taskRepo      domain.TaskRepository
+	projectLookup domain.ProjectLookup
 }
 
-func NewGetUserTasksUseCase(taskRepo domain.TaskRepository) *GetUserTasksUseCase {
+func NewGetUserTasksUseCase(taskRepo domain.TaskRepository, projectLookup domain.ProjectLookup) *GetUserTasksUseCase {
 	return &GetUserTasksUseCase{
-		taskRepo: taskRepo,
+		taskRepo:      taskRepo,
+		projectLookup: projectLookup,
 	}
 }
 
-func (uc *GetUserTasksUseCase) Execute(ctx context.Context, userID string, status string, priority *int, projectID *string) ([]*dto.TaskResponse, error) {
+// Execute lists a page of the user's tasks. An invalid filter expression is
+// reported as a *filter.ParseError carrying the offending position.
+func (uc *GetUserTasksUseCase) Execute(ctx context.Context, userID string, req dto.ListTasksRequest) (*dto.TaskListResponse, error) {
 	// Validate input
 	if userID == "" {
 		return nil, apperrors.NewBadRequestError("user ID is required")
 	}
 
-	// Get tasks from repository
-	tasks, err := uc.taskRepo.GetByUserID(userID)
+	query, err := uc.buildQuery(ctx, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch one task beyond the page to learn whether another page exists
+	pageSize := query.Limit
+	query.Limit++
+	tasks, err := uc.taskRepo.Find(userID, query)
 	if err != nil {
 		return nil, apperrors.NewInternalError("failed to get tasks", err)
 	}
 
-	// Filter tasks based on criteria
-	var filteredTasks []*domain.Task
+	response := &dto.TaskListResponse{Data: []*dto.TaskResponse{}}
+	if len(tasks) > pageSize {
+		tasks = tasks[:pageSize]
+		next := encodeCursor(domain.CursorAfter(tasks[len(tasks)-1], query.Sort, query.Descending))
+		response.NextCursor = &next
+		response.HasMore = true
+	}
+
+	// Convert to response DTOs
 	for _, task := range tasks {
-		// Filter by status
-		if status != "" && task.Status != status {
-			continue
-		}
+		response.Data = append(response.Data, mapper.ToTaskResponse(task))
+	}
+	response.Total = len(response.Data)
 
-		// Filter by priority
-		if priority != nil && task.Priority != *priority {
-			continue
-		}
+	return response, nil
+}
 
-		// Filter by project ID
-		if projectID != nil {
-			if task.ProjectID == nil || *task.ProjectID != *projectID {
-				continue
-			}
-		}
+// buildQuery turns the request into a repository query
+func (uc *GetUserTasksUseCase) buildQuery(ctx context.Context, userID string, req dto.ListTasksRequest) (domain.TaskQuery, error) {
+	query, err := buildFilteredQuery(ctx, uc.projectLookup, userID, req.TaskFilterParams)
+	if err != nil {
+		return query, err
+	}
 
-		filteredTasks = append(filteredTasks, task)
+	// Sorting and pagination
+	sort, err := domain.ParseTaskSort(req.Sort)
+	if err != nil {
+		return query, apperrors.NewBadRequestError(err.Error())
+	}
+	query.Sort = sort
+	switch strings.ToLower(req.Direction) {
+	case "":
+		query.Descending = sort.DefaultDescending()
+	case "asc":
+		query.Descending = false
+	case "desc":
+		query.Descending = true
+	default:
+		return query, apperrors.NewBadRequestError("direction must be asc or desc")
 	}
 
-	// Convert to response DTOs
-	var taskResponses []*dto.TaskResponse
-	for _, task := range filteredTasks {
-		taskResponses = append(taskResponses, mapper.ToTaskResponse(task))
+	query.Limit, err = pageSize(req.Limit, defaultTaskPageSize, maxTaskPageSize)
+	if err != nil {
+		return query, err
+	}
+
+	if req.Cursor != "" {
+		query.After, err = decodeCursor(req.Cursor, query.Sort, query.Descending)
+		if err != nil {
+			return query, err
+		}
 	}
 
-	return taskResponses, nil
+	return query, nil
 }
@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+	"github.com/todoist/backend/task-service/domain/quickadd"
+)
+
+// Import file formats
+const (
+	ImportFormatTodoist = "todoist"
+	ImportFormatCSV     = "csv"
+)
+
+// Task fields a generic CSV column can be mapped to. due takes a date,
+// a datetime or a phrase like "every monday", as Todoist's DATE column does.
+var importFields = map[string]bool{
+	"title": true, "description": true, "priority": true, "due": true,
+	"due_date": true, "due_datetime": true, "due_timezone": true, "recurrence": true,
+	"labels": true, "estimated_minutes": true, "id": true, "parent_id": true,
+}
+
+// maxImportIndent is the deepest nesting of Todoist's INDENT column
+const maxImportIndent = 5
+
+// importRow is a task read from one row of an import file
+type importRow struct {
+	line int
+	// skip tells why a row that is not a task is left out
+	skip string
+	// err tells why the row cannot be imported
+	err error
+	// indent nests the row under the closest row above it with a lower
+	// indent, from 1 for top level tasks; 0 when the format has none
+	indent int
+	// ref is the row's ID in the file, which parentRef of later rows refers
+	// to
+	ref       string
+	parentRef string
+	// due holds the due date cells as written, with the zone they are read
+	// in: relative dates such as "tomorrow" resolve differently every day,
+	// so the import key is derived from these
+	due  []string
+	task dto.CreateTaskRequest
+}
+
+// importReader reads an import file a row at a time; Next returns io.EOF
+// after the last row
+type importReader interface {
+	Next() (*importRow, error)
+}
+
+// csvRows reads the records of a CSV file after its header
+type csvRows struct {
+	reader  *csv.Reader
+	columns map[string]int // header, as normalized by the format, to index
+}
+
+func newCSVRows(content io.Reader, normalize func(string) string) (*csvRows, error) {
+	reader := csv.NewReader(bufio.NewReader(content))
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, apperrors.NewBadRequestError("file is empty")
+	}
+	if err != nil {
+		return nil, importReadError(err)
+	}
+
+	rows := &csvRows{reader: reader, columns: make(map[string]int, len(header))}
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		key := normalize(name)
+		if _, ok := rows.columns[key]; !ok && key != "" {
+			rows.columns[key] = i
+		}
+	}
+	return rows, nil
+}
+
+// next returns the next record that is not blank with its line, or a row
+// holding the error of a malformed record
+func (r *csvRows) next() ([]string, *importRow, error) {
+	for {
+		record, err := r.reader.Read()
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &importRow{line: parseErr.StartLine, err: parseErr.Err}, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		line, _ := r.reader.FieldPos(0)
+		for _, field := range record {
+			if strings.TrimSpace(field) != "" {
+				return record, &importRow{line: line}, nil
+			}
+		}
+	}
+}
+
+// field returns the trimmed value of a column, empty when the file has no
+// such column
+func (r *csvRows) field(record []string, column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// todoistReader reads Todoist's CSV template: TYPE, CONTENT, DESCRIPTION,
+// PRIORITY, INDENT, DATE, TIMEZONE, DURATION and DURATION_UNIT columns,
+// with labels written as @label in CONTENT. PRIORITY is the level, 1 being
+// the highest, and subtasks are rows with a deeper INDENT.
+type todoistReader struct {
+	rows *csvRows
+	loc  *time.Location
+	now  time.Time
+}
+
+func newTodoistReader(content io.Reader, loc *time.Location, now time.Time) (*todoistReader, error) {
+	rows, err := newCSVRows(content, func(name string) string {
+		return strings.ToUpper(strings.TrimSpace(name))
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, column := range []string{"TYPE", "CONTENT"} {
+		if _, ok := rows.columns[column]; !ok {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("file has no %s column", column))
+		}
+	}
+	return &todoistReader{rows: rows, loc: loc, now: now}, nil
+}
+
+func (r *todoistReader) Next() (*importRow, error) {
+	record, row, err := r.rows.next()
+	if err != nil || row.err != nil {
+		return row, err
+	}
+
+	switch kind := strings.ToLower(r.rows.field(record, "TYPE")); kind {
+	case "task":
+	case "note", "section":
+		row.skip = kind + "s are not imported"
+		return row, nil
+	default:
+		row.err = fmt.Errorf("unknown type %q", kind)
+		return row, nil
+	}
+
+	row.err = r.parse(record, row)
+	return row, nil
+}
+
+func (r *todoistReader) parse(record []string, row *importRow) error {
+	row.indent = 1
+	if value := r.rows.field(record, "INDENT"); value != "" {
+		indent, err := strconv.Atoi(value)
+		if err != nil || indent < 1 || indent > maxImportIndent {
+			return fmt.Errorf("indent must be 1 to %d", maxImportIndent)
+		}
+		row.indent = indent
+	}
+
+	// Labels are written into the content; nothing else is read from it
+	content := quickadd.Parse(r.rows.field(record, "CONTENT"), r.now)
+	for _, kind := range []quickadd.SpanKind{quickadd.SpanDue, quickadd.SpanRecurrence, quickadd.SpanPriority, quickadd.SpanProject} {
+		content.Unextract(kind)
+	}
+	row.task.Title = content.Title()
+	row.task.Labels = content.Labels
+	row.task.Description = r.rows.field(record, "DESCRIPTION")
+
+	if value := r.rows.field(record, "PRIORITY"); value != "" {
+		level, err := strconv.Atoi(value)
+		if err != nil || level < 1 || level > 4 {
+			return fmt.Errorf("priority must be 1 to 4")
+		}
+		row.task.Priority = domain.PriorityForLevel(level)
+	}
+
+	loc := r.loc
+	if timezone := r.rows.field(record, "TIMEZONE"); timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil {
+			return fmt.Errorf("invalid timezone %q", timezone)
+		}
+	}
+	row.due = []string{r.rows.field(record, "DATE"), loc.String()}
+	if err := parseImportDue(&row.task, r.rows.field(record, "DATE"), loc, r.now); err != nil {
+		return err
+	}
+
+	if value := r.rows.field(record, "DURATION"); value != "" {
+		duration, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("duration must be a number")
+		}
+		switch strings.ToLower(r.rows.field(record, "DURATION_UNIT")) {
+		case "", "minute", "minutes":
+		case "day", "days":
+			duration *= 24 * 60
+		default:
+			return fmt.Errorf("duration unit must be minute or day")
+		}
+		row.task.EstimatedMinutes = &duration
+	}
+	return nil
+}
+
+// csvReader reads a generic CSV whose columns are mapped to task fields.
+// priority is the stored priority, 4 being the highest, or a level p1 to
+// p4; labels are comma separated; id and parent_id nest rows under earlier
+// rows.
+type csvReader struct {
+	rows   *csvRows
+	fields map[string]string // task field to normalized column header
+	loc    *time.Location
+	now    time.Time
+}
+
+func newCSVReader(content io.Reader, mapping map[string]string, loc *time.Location, now time.Time) (*csvReader, error) {
+	normalize := func(name string) string {
+		return strings.ToLower(strings.TrimSpace(name))
+	}
+
+	fields := make(map[string]string, len(mapping))
+	for field, column := range mapping {
+		if !importFields[field] {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("unknown field %q in mapping", field))
+		}
+		fields[field] = normalize(column)
+	}
+
+	rows, err := newCSVRows(content, normalize)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a mapping, columns are matched to fields by name
+	if len(fields) == 0 {
+		for column := range rows.columns {
+			if importFields[column] {
+				fields[column] = column
+			}
+		}
+	}
+	if _, ok := fields["title"]; !ok {
+		return nil, apperrors.NewBadRequestError("no column is mapped to title")
+	}
+	names := make([]string, 0, len(fields))
+	for field := range fields {
+		names = append(names, field)
+	}
+	sort.Strings(names)
+	for _, field := range names {
+		if _, ok := rows.columns[fields[field]]; !ok {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("column %q mapped to %s is not in the file", mapping[field], field))
+		}
+	}
+
+	return &csvReader{rows: rows, fields: fields, loc: loc, now: now}, nil
+}
+
+func (r *csvReader) field(record []string, field string) string {
+	column, ok := r.fields[field]
+	if !ok {
+		return ""
+	}
+	return r.rows.field(record, column)
+}
+
+// optionalField returns a field that is set, nil for an empty one
+func (r *csvReader) optionalField(record []string, field string) *string {
+	if value := r.field(record, field); value != "" {
+		return &value
+	}
+	return nil
+}
+
+func (r *csvReader) Next() (*importRow, error) {
+	record, row, err := r.rows.next()
+	if err != nil || row.err != nil {
+		return row, err
+	}
+	row.err = r.parse(record, row)
+	return row, nil
+}
+
+func (r *csvReader) parse(record []string, row *importRow) error {
+	row.ref = r.field(record, "id")
+	row.parentRef = r.field(record, "parent_id")
+	row.task.Title = r.field(record, "title")
+	row.task.Description = r.field(record, "description")
+
+	if value := r.field(record, "priority"); value != "" {
+		priority, err := parseImportPriority(value)
+		if err != nil {
+			return err
+		}
+		row.task.Priority = priority
+	}
+
+	row.due = []string{
+		r.field(record, "due"), r.field(record, "due_date"), r.field(record, "due_datetime"),
+		r.field(record, "due_timezone"), r.field(record, "recurrence"), r.loc.String(),
+	}
+	if err := parseImportDue(&row.task, r.field(record, "due"), r.loc, r.now); err != nil {
+		return err
+	}
+	if value := r.optionalField(record, "due_date"); value != nil {
+		row.task.DueDate = value
+	}
+	if value := r.optionalField(record, "due_datetime"); value != nil {
+		row.task.DueDatetime = value
+	}
+	if value := r.optionalField(record, "due_timezone"); value != nil {
+		row.task.DueTimezone = value
+	}
+	if value := r.optionalField(record, "recurrence"); value != nil {
+		row.task.Recurrence = value
+	}
+
+	for _, label := range strings.Split(r.field(record, "labels"), ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			row.task.Labels = append(row.task.Labels, label)
+		}
+	}
+
+	if value := r.field(record, "estimated_minutes"); value != "" {
+		minutes, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("estimated_minutes must be a number")
+		}
+		row.task.EstimatedMinutes = &minutes
+	}
+	return nil
+}
+
+// parseImportPriority reads a stored priority 1 to 4 or a level p1 to p4
+func parseImportPriority(value string) (int, error) {
+	if level, ok := strings.CutPrefix(strings.ToLower(value), "p"); ok {
+		if n, err := strconv.Atoi(level); err == nil && n >= 1 && n <= 4 {
+			return domain.PriorityForLevel(n), nil
+		}
+	} else if n, err := strconv.Atoi(value); err == nil && n >= 1 && n <= 4 {
+		return n, nil
+	}
+	return 0, fmt.Errorf("priority must be 1 to 4 or p1 to p4")
+}
+
+// parseImportDue reads a due date written as a date, an RFC 3339 datetime
+// or a phrase quick add understands, such as "tomorrow at 5pm" or "every
+// monday", in loc
+func parseImportDue(req *dto.CreateTaskRequest, text string, loc *time.Location, now time.Time) error {
+	if text == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, text); err == nil {
+		req.DueDatetime = &text
+		return nil
+	}
+	if _, err := time.Parse(domain.DueDateLayout, text); err == nil {
+		req.DueDate = &text
+		return nil
+	}
+
+	// The whole text has to be read as a date or recurrence
+	result := quickadd.Parse(text, now.In(loc))
+	if result.Title() != "" || result.Project != "" || len(result.Labels) > 0 || result.PriorityLevel != 0 ||
+		(result.Due == nil && result.Recurrence == "") {
+		return fmt.Errorf("unrecognized date %q", text)
+	}
+	timezone := ""
+	if loc != time.UTC {
+		timezone = loc.String()
+	}
+	setQuickAddDue(req, result, timezone)
+	return nil
+}
@@ -0,0 +1,400 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// Outcomes of an imported row
+const (
+	ImportRowCreated   = "created"
+	ImportRowDuplicate = "duplicate"
+	ImportRowSkipped   = "skipped"
+	ImportRowFailed    = "failed"
+)
+
+const (
+	maxImportRows    = 50000
+	maxImportResults = 100
+	maxImportErrors  = 1000
+)
+
+type ImportTasksUseCase struct {
+	transactor   domain.Transactor
+	importRepo   domain.TaskImportRepository
+	createTaskUC *CreateTaskUseCase
+}
+
+func NewImportTasksUseCase(transactor domain.Transactor, importRepo domain.TaskImportRepository) *ImportTasksUseCase {
+	return &ImportTasksUseCase{
+		transactor:   transactor,
+		importRepo:   importRepo,
+		createTaskUC: NewCreateTaskUseCase(transactor),
+	}
+}
+
+// importedRow is what later rows need to know about a row: subtasks go
+// under its task and derive their key from its key
+type importedRow struct {
+	line   int
+	key    string
+	taskID string
+	ok     bool
+}
+
+// importRun is the state of one import
+type importRun struct {
+	userID    string
+	projectID *string
+	dryRun    bool
+	now       time.Time
+	// parents holds the last row of each indent level, refs the rows by
+	// their ID in the file
+	parents  []*importedRow
+	refs     map[string]*importedRow
+	seen     map[string]int
+	response *dto.ImportTasksResponse
+}
+
+// Execute imports the file a row at a time, each task in a transaction of
+// its own, so a failed row is reported and does not stop the rest. Every
+// imported row is recorded under a key derived from its content, its
+// parent's key and the target project, or from its ID for formats that have
+// one, and a row imported before is skipped: running an import again
+// creates only what is missing. A dry run validates the rows and reports
+// what would happen without writing anything.
+func (uc *ImportTasksUseCase) Execute(ctx context.Context, userID string, req dto.ImportTasksRequest) (*dto.ImportTasksResponse, error) {
+	// Validate inputs
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+	if req.ProjectID != nil && *req.ProjectID == "" {
+		req.ProjectID = nil
+	}
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid timezone")
+	}
+
+	now := time.Now()
+	var reader importReader
+	switch req.Format {
+	case "", ImportFormatTodoist:
+		if len(req.Mapping) > 0 {
+			return nil, apperrors.NewBadRequestError("mapping is only used with the csv format")
+		}
+		reader, err = newTodoistReader(req.Content, loc, now)
+	case ImportFormatCSV:
+		reader, err = newCSVReader(req.Content, req.Mapping, loc, now)
+	default:
+		return nil, apperrors.NewBadRequestError("format must be todoist or csv")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	run := &importRun{
+		userID:    userID,
+		projectID: req.ProjectID,
+		dryRun:    req.DryRun,
+		now:       now,
+		refs:      make(map[string]*importedRow),
+		seen:      make(map[string]int),
+		response: &dto.ImportTasksResponse{
+			DryRun:  req.DryRun,
+			Results: []*dto.ImportRowResult{},
+			Errors:  []*dto.ImportRowResult{},
+		},
+	}
+	for {
+		row, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, importReadError(err)
+		}
+		if run.response.Rows == maxImportRows {
+			run.record(row.line, ImportRowFailed, "", nil, fmt.Sprintf("imports stop after %d rows", maxImportRows))
+			break
+		}
+		run.response.Rows++
+		uc.importRow(ctx, run, row)
+	}
+
+	return run.response, nil
+}
+
+// importRow imports one row and records the outcome
+func (uc *ImportTasksUseCase) importRow(ctx context.Context, run *importRun, row *importRow) {
+	if row.skip != "" {
+		run.record(row.line, ImportRowSkipped, row.task.Title, nil, row.skip)
+		return
+	}
+
+	entry := &importedRow{line: row.line}
+	parent, err := run.place(row, entry)
+	if err == nil && row.err != nil {
+		err = apperrors.NewBadRequestError(row.err.Error())
+	}
+	if err == nil && parent != nil && !parent.ok {
+		err = apperrors.NewBadRequestError(fmt.Sprintf("parent row %d was not imported", parent.line))
+	}
+	if err != nil {
+		run.fail(row, parent, err)
+		return
+	}
+
+	// Subtasks take their parent's project
+	if parent != nil {
+		row.task.ParentID = &parent.taskID
+	} else {
+		row.task.ProjectID = run.projectID
+	}
+	entry.key = run.key(row, parent)
+
+	status := ImportRowCreated
+	if run.dryRun {
+		status, err = uc.preview(run, row, entry)
+	} else {
+		status, err = uc.create(ctx, run, row, entry)
+	}
+	if err != nil {
+		run.fail(row, parent, err)
+		return
+	}
+
+	entry.ok = true
+	var taskID *string
+	if entry.taskID != "" {
+		taskID = &entry.taskID
+	}
+	result := run.record(row.line, status, row.task.Title, taskID, "")
+	if parent != nil {
+		result.ParentRow = &parent.line
+	}
+}
+
+// preview validates a row for a dry run and reports whether it was
+// imported before
+func (uc *ImportTasksUseCase) preview(run *importRun, row *importRow, entry *importedRow) (string, error) {
+	if err := validateImportTask(row.task, run.now); err != nil {
+		return "", err
+	}
+
+	record, err := uc.importRepo.Get(run.userID, entry.key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ImportRowCreated, nil
+	}
+	if err != nil {
+		return "", apperrors.NewInternalError("failed to check import", err)
+	}
+	if record.TaskID != nil {
+		entry.taskID = *record.TaskID
+	}
+	return ImportRowDuplicate, nil
+}
+
+// create imports a row unless it was imported before
+func (uc *ImportTasksUseCase) create(ctx context.Context, run *importRun, row *importRow, entry *importedRow) (string, error) {
+	status := ImportRowCreated
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		claimed, err := repos.Imports.Claim(&domain.TaskImport{UserID: run.userID, Key: entry.key, CreatedAt: run.now})
+		if err != nil {
+			return apperrors.NewInternalError("failed to record import", err)
+		}
+		if !claimed {
+			record, err := repos.Imports.Get(run.userID, entry.key)
+			if err != nil {
+				return apperrors.NewInternalError("failed to get import", err)
+			}
+			if record.TaskID != nil {
+				entry.taskID = *record.TaskID
+			}
+			status = ImportRowDuplicate
+			return nil
+		}
+
+		task, err := uc.createTaskUC.create(repos, row.task, run.userID)
+		if err != nil {
+			return err
+		}
+		if err := repos.Imports.SetTaskID(run.userID, entry.key, task.ID); err != nil {
+			return apperrors.NewInternalError("failed to record import", err)
+		}
+		entry.taskID = task.ID
+		return nil
+	})
+	return status, err
+}
+
+// place finds the parent of a row and makes the row available as a parent
+// to the rows after it
+func (run *importRun) place(row *importRow, entry *importedRow) (*importedRow, error) {
+	if row.indent > 0 {
+		if row.indent-1 > len(run.parents) {
+			run.parents = append(run.parents, entry)
+			return nil, apperrors.NewBadRequestError("indent is deeper than the row above allows")
+		}
+		run.parents = append(run.parents[:row.indent-1], entry)
+		if row.indent == 1 {
+			return nil, nil
+		}
+		return run.parents[row.indent-2], nil
+	}
+
+	if row.ref != "" {
+		if _, ok := run.refs[row.ref]; ok {
+			return nil, apperrors.NewBadRequestError(fmt.Sprintf("id %q is used by an earlier row", row.ref))
+		}
+		run.refs[row.ref] = entry
+	}
+	if row.parentRef == "" {
+		return nil, nil
+	}
+	parent, ok := run.refs[row.parentRef]
+	if !ok {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("parent_id %q does not match an earlier row", row.parentRef))
+	}
+	return parent, nil
+}
+
+// key derives a row's import key from the row as written, so importing the
+// same file on another day gives the same keys. Identical rows under the
+// same parent are told apart by their order.
+func (run *importRun) key(row *importRow, parent *importedRow) string {
+	parts := []string{run.userID, derefOr(run.projectID, "")}
+	if parent != nil {
+		parts = append(parts, parent.key)
+	} else {
+		parts = append(parts, "")
+	}
+
+	if row.ref != "" {
+		return importHash(append(parts, "id", row.ref))
+	}
+
+	task := row.task
+	labels := append([]string(nil), task.Labels...)
+	sort.Strings(labels)
+	estimate := ""
+	if task.EstimatedMinutes != nil {
+		estimate = strconv.Itoa(*task.EstimatedMinutes)
+	}
+	parts = append(parts, "task", task.Title, task.Description, strconv.Itoa(task.Priority))
+	parts = append(parts, row.due...)
+	parts = append(parts, strings.Join(labels, ","), estimate)
+	key := importHash(parts)
+
+	run.seen[key]++
+	if n := run.seen[key]; n > 1 {
+		key = importHash([]string{key, strconv.Itoa(n)})
+	}
+	return key
+}
+
+// fail records a row that could not be imported
+func (run *importRun) fail(row *importRow, parent *importedRow, err error) {
+	_, message := batchFailure(err)
+	result := run.record(row.line, ImportRowFailed, row.task.Title, nil, message)
+	if parent != nil {
+		result.ParentRow = &parent.line
+	}
+}
+
+// record counts the outcome of a row and adds it to the results while
+// there is room, and failures to the errors
+func (run *importRun) record(line int, status, title string, taskID *string, message string) *dto.ImportRowResult {
+	response := run.response
+	switch status {
+	case ImportRowCreated:
+		response.Created++
+	case ImportRowDuplicate:
+		response.Duplicates++
+	case ImportRowSkipped:
+		response.Skipped++
+	case ImportRowFailed:
+		response.Failed++
+	}
+
+	result := &dto.ImportRowResult{Row: line, Status: status, Title: title, TaskID: taskID, Error: message}
+	if status == ImportRowFailed {
+		if len(response.Errors) < maxImportErrors {
+			response.Errors = append(response.Errors, result)
+		} else {
+			response.ErrorsTruncated = true
+		}
+	}
+	if len(response.Results) < maxImportResults {
+		response.Results = append(response.Results, result)
+	}
+	return result
+}
+
+// validateImportTask checks a row for a dry run the way creating its task
+// would
+func validateImportTask(req dto.CreateTaskRequest, now time.Time) error {
+	if req.Title == "" {
+		return apperrors.NewBadRequestError("title is required")
+	}
+
+	task := &domain.Task{}
+	due, err := parseDue(req.DueDate, req.DueDatetime, req.DueTimezone)
+	if err != nil {
+		return err
+	}
+	task.SetDue(due)
+	if req.EstimatedMinutes != nil {
+		if err := task.SetEstimate(*req.EstimatedMinutes); err != nil {
+			return apperrors.NewBadRequestError(err.Error())
+		}
+	}
+	if req.Recurrence != nil && *req.Recurrence != "" {
+		if err := task.SetRecurrence(*req.Recurrence, now); err != nil {
+			return apperrors.NewBadRequestError(err.Error())
+		}
+	}
+	for _, label := range req.Labels {
+		if _, err := domain.NormalizeLabelName(label); err != nil {
+			return apperrors.NewBadRequestError(err.Error())
+		}
+	}
+	return nil
+}
+
+func importHash(parts []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x1f")))
+	return hex.EncodeToString(sum[:])
+}
+
+func derefOr(s *string, fallback string) string {
+	if s == nil {
+		return fallback
+	}
+	return *s
+}
+
+// importReadError reports a failure to read the file, which the handler's
+// body limit can cause as well. Rows imported before it stay imported.
+func importReadError(err error) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return apperrors.NewPayloadTooLargeError("import file is too large")
+	}
+	return apperrors.NewBadRequestError("failed to read file")
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type MoveTaskUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewMoveTaskUseCase(transactor domain.Transactor) *MoveTaskUseCase {
+	return &MoveTaskUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute moves a task together with its subtree under a new parent or to
+// the top level of a project, optionally right before or after another task
+func (uc *MoveTaskUseCase) Execute(ctx context.Context, taskID, userID string, req dto.MoveTaskRequest) (*dto.TaskResponse, error) {
+	// Validate inputs
+	if taskID == "" {
+		return nil, apperrors.NewBadRequestError("task ID is required")
+	}
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	var moved *domain.Task
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		var err error
+		moved, err = uc.move(repos, taskID, userID, req)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToTaskResponse(moved), nil
+}
+
+// move reparents the subtree and places it among its new siblings inside a
+// transaction, and records its events
+func (uc *MoveTaskUseCase) move(repos domain.Repositories, taskID, userID string, req dto.MoveTaskRequest) (*domain.Task, error) {
+	if req.Before != nil && req.After != nil {
+		return nil, apperrors.NewBadRequestError("only one of before and after can be given")
+	}
+
+	// Get existing task
+	task, err := repos.Tasks.GetByID(taskID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("task not found")
+	}
+
+	// Verify task belongs to user
+	if task.UserID != userID {
+		return nil, apperrors.NewForbiddenError("access denied to this task")
+	}
+
+	// Snapshot the subtree, which doubles as the cycle check below and as
+	// the before state for events
+	subtree, err := repos.Tasks.GetSubtree(task.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get subtasks", err)
+	}
+	before := task.Clone()
+
+	anchor, err := uc.getAnchor(repos, task, userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	parentID, projectID := req.ParentID, task.ProjectID
+	if req.ProjectID != nil {
+		projectID = req.ProjectID
+	}
+
+	if req.ParentID != nil {
+		parent, err := repos.Tasks.GetByID(*req.ParentID)
+		if err != nil {
+			return nil, apperrors.NewNotFoundError("parent task not found")
+		}
+		if parent.UserID != userID {
+			return nil, apperrors.NewForbiddenError("access denied to parent task")
+		}
+
+		// A task cannot become a descendant of itself
+		for _, descendant := range subtree {
+			if descendant.ID == parent.ID {
+				return nil, apperrors.NewBadRequestError("cannot move a task under itself or one of its subtasks")
+			}
+		}
+
+		projectID = parent.ProjectID
+	}
+
+	// A pure reorder follows the anchor into its project, keeping the
+	// parent unless the task leaves the parent's project
+	if anchor != nil && req.ParentID == nil && req.ProjectID == nil {
+		parentID, projectID = task.ParentID, anchor.ProjectID
+		if !sameProject(task.ProjectID, projectID) {
+			parentID = nil
+		}
+	}
+	if anchor != nil && !sameProject(anchor.ProjectID, projectID) {
+		return nil, apperrors.NewBadRequestError("the anchor task is in a different project")
+	}
+
+	if err := repos.Tasks.MoveSubtree(task.ID, parentID, projectID); err != nil {
+		return nil, apperrors.NewInternalError("failed to move task", err)
+	}
+	if !sameProject(task.ProjectID, projectID) {
+		if err := appendSubtree(repos.Tasks, userID, projectID, subtree); err != nil {
+			return nil, err
+		}
+	}
+	if anchor != nil {
+		position, err := positionNextTo(repos.Tasks, anchor, req.After != nil)
+		if err != nil {
+			return nil, err
+		}
+		if err := repos.Tasks.SetPosition(task.ID, position); err != nil {
+			return nil, apperrors.NewInternalError("failed to update task position", err)
+		}
+	}
+
+	task.ParentID = parentID
+	task.ProjectID = projectID
+
+	// An open task moved under a completed parent reopens its ancestors
+	if !task.IsCompleted() {
+		if err := applyHierarchyStatusRules(repos, task, true, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
+	moved, err := repos.Tasks.GetByID(task.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get task", err)
+	}
+
+	if err := recordTaskUpdated(repos, before, moved); err != nil {
+		return nil, err
+	}
+	if err := recordSubtreeChanges(repos, task.ID, subtree, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return moved, nil
+}
+
+// getAnchor loads the task named by Before or After, nil when neither is
+// given
+func (uc *MoveTaskUseCase) getAnchor(repos domain.Repositories, task *domain.Task, userID string, req dto.MoveTaskRequest) (*domain.Task, error) {
+	anchorID := req.Before
+	if req.After != nil {
+		anchorID = req.After
+	}
+	if anchorID == nil {
+		return nil, nil
+	}
+	if *anchorID == task.ID {
+		return nil, apperrors.NewBadRequestError("a task cannot be placed next to itself")
+	}
+
+	anchor, err := repos.Tasks.GetByID(*anchorID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("anchor task not found")
+	}
+	if anchor.UserID != userID {
+		return nil, apperrors.NewForbiddenError("access denied to anchor task")
+	}
+	return anchor, nil
+}
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/application/patch"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// mutableTaskFields are the members of a task document a patch may change.
+// Every other member of TaskResponse is read-only; parent_id changes go
+// through MoveTaskUseCase.
+var mutableTaskFields = map[string]bool{
+	"title":             true,
+	"description":       true,
+	"status":            true,
+	"priority":          true,
+	"project_id":        true,
+	"due_date":          true,
+	"due_datetime":      true,
+	"due_timezone":      true,
+	"recurrence":        true,
+	"labels":            true,
+	"estimated_minutes": true,
+}
+
+// taskDocument is the typed form of the mutable members of a patched task.
+// Absent and null members both decode to nil.
+type taskDocument struct {
+	Title            *string   `json:"title"`
+	Description      *string   `json:"description"`
+	Status           *string   `json:"status"`
+	Priority         *int      `json:"priority"`
+	ProjectID        *string   `json:"project_id"`
+	DueDate          *string   `json:"due_date"`
+	DueDatetime      *string   `json:"due_datetime"`
+	DueTimezone      *string   `json:"due_timezone"`
+	Recurrence       *string   `json:"recurrence"`
+	Labels           *[]string `json:"labels"`
+	EstimatedMinutes *int      `json:"estimated_minutes"`
+}
+
+type PatchTaskUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewPatchTaskUseCase(transactor domain.Transactor) *PatchTaskUseCase {
+	return &PatchTaskUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute applies a JSON Merge Patch or JSON Patch to the task's JSON
+// representation. Unlike UpdateTaskUseCase, explicit nulls and zero values
+// are honored. A non-nil ifMatch must equal the task's current version.
+func (uc *PatchTaskUseCase) Execute(ctx context.Context, taskID, userID string, req dto.PatchTaskRequest, ifMatch *int) (*dto.TaskResponse, error) {
+	var task *domain.Task
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		var err error
+		task, err = getOwnedTask(repos.Tasks, taskID, userID)
+		if err != nil {
+			return err
+		}
+		if err := checkTaskVersion(task, ifMatch); err != nil {
+			return err
+		}
+
+		changes, err := patchTaskChanges(task, req)
+		if err != nil {
+			return err
+		}
+		if changes.isEmpty() {
+			return nil
+		}
+		changes.Force = req.Force
+
+		task, err = applyTaskChanges(repos, task, changes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToTaskResponse(task), nil
+}
+
+// patchTaskChanges applies the patch to the task's representation and
+// returns the fields whose value it changed
+func patchTaskChanges(task *domain.Task, req dto.PatchTaskRequest) (taskChanges, error) {
+	original, err := taskDocumentValue(task)
+	if err != nil {
+		return taskChanges{}, apperrors.NewInternalError("failed to encode task", err)
+	}
+
+	var patched interface{}
+	switch req.ContentType {
+	case patch.MergePatchContentType:
+		fields, err := patch.MergePatchFields(req.Body)
+		if err != nil {
+			return taskChanges{}, patchError(err)
+		}
+		patched = patch.ApplyMergePatch(original, fields)
+	case patch.JSONPatchContentType:
+		ops, err := patch.DecodeJSONPatch(req.Body)
+		if err != nil {
+			return taskChanges{}, patchError(err)
+		}
+		// Patch a copy; the original is needed to compute the changes
+		working, _ := taskDocumentValue(task)
+		patched, err = patch.ApplyJSONPatch(working, ops)
+		if err != nil {
+			return taskChanges{}, patchError(err)
+		}
+	default:
+		return taskChanges{}, apperrors.NewBadRequestError(fmt.Sprintf(
+			"unsupported patch content type, use %s or %s", patch.MergePatchContentType, patch.JSONPatchContentType))
+	}
+
+	patchedObject, ok := patched.(map[string]interface{})
+	if !ok {
+		return taskChanges{}, apperrors.NewValidationError("patched task must be a JSON object")
+	}
+	if err := checkPatchedFields(original, patchedObject); err != nil {
+		return taskChanges{}, err
+	}
+
+	before, err := decodeTaskDocument(original)
+	if err != nil {
+		return taskChanges{}, apperrors.NewInternalError("failed to decode task", err)
+	}
+	after, err := decodeTaskDocument(patchedObject)
+	if err != nil {
+		return taskChanges{}, err
+	}
+
+	return diffTaskDocuments(before, after)
+}
+
+// checkPatchedFields rejects patches that add unknown members or touch
+// read-only ones
+func checkPatchedFields(original, patched map[string]interface{}) error {
+	var keys []string
+	for key := range original {
+		keys = append(keys, key)
+	}
+	for key := range patched {
+		if _, ok := original[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if mutableTaskFields[key] {
+			continue
+		}
+		before, known := original[key]
+		if !known {
+			return apperrors.NewValidationError(fmt.Sprintf("unknown field %q", key))
+		}
+		if after, ok := patched[key]; !ok || !reflect.DeepEqual(before, after) {
+			return apperrors.NewValidationError(fmt.Sprintf("field %q is read-only", key))
+		}
+	}
+	return nil
+}
+
+// diffTaskDocuments validates the patched values and turns the members that
+// differ into task changes
+func diffTaskDocuments(before, after *taskDocument) (taskChanges, error) {
+	var changes taskChanges
+
+	if !reflect.DeepEqual(before.Title, after.Title) {
+		if after.Title == nil || strings.TrimSpace(*after.Title) == "" {
+			return changes, apperrors.NewValidationError("title cannot be empty")
+		}
+		changes.Title = after.Title
+	}
+	if !reflect.DeepEqual(before.Description, after.Description) {
+		description := ""
+		if after.Description != nil {
+			description = *after.Description
+		}
+		changes.Description = &description
+	}
+	if !reflect.DeepEqual(before.Status, after.Status) {
+		if after.Status == nil || (*after.Status != domain.TaskStatusPending && *after.Status != domain.TaskStatusCompleted) {
+			return changes, apperrors.NewValidationError(domain.ErrInvalidTaskStatus.Error())
+		}
+		changes.Status = after.Status
+	}
+	if !reflect.DeepEqual(before.Priority, after.Priority) {
+		priority := 0
+		if after.Priority != nil {
+			priority = *after.Priority
+		}
+		if priority < 0 || priority > 4 {
+			return changes, apperrors.NewValidationError("priority must be between 0 and 4")
+		}
+		changes.Priority = &priority
+	}
+	if !reflect.DeepEqual(before.ProjectID, after.ProjectID) {
+		changes.SetProject = true
+		changes.ProjectID = after.ProjectID
+	}
+	if due, changed, err := patchedDue(before, after); err != nil {
+		return changes, err
+	} else if changed {
+		changes.SetDue = true
+		changes.Due = due
+	}
+	if !reflect.DeepEqual(before.Recurrence, after.Recurrence) {
+		recurrence := ""
+		if after.Recurrence != nil {
+			recurrence = *after.Recurrence
+		}
+		changes.Recurrence = &recurrence
+	}
+	if !reflect.DeepEqual(before.Labels, after.Labels) {
+		changes.SetLabels = true
+		changes.Labels = []string{}
+		if after.Labels != nil {
+			changes.Labels = *after.Labels
+		}
+	}
+	if !reflect.DeepEqual(before.EstimatedMinutes, after.EstimatedMinutes) {
+		estimate := 0
+		if after.EstimatedMinutes != nil {
+			estimate = *after.EstimatedMinutes
+		}
+		if estimate < 0 || estimate > domain.MaxEstimatedMinutes {
+			return changes, apperrors.NewValidationError(domain.ErrInvalidEstimate.Error())
+		}
+		changes.EstimatedMinutes = &estimate
+	}
+
+	return changes, nil
+}
+
+// patchedDue works out the due value from the patched due members. A
+// changed datetime or time zone sets a timed due date. Otherwise the date
+// wins, so changing it or removing the datetime makes the task all-day on
+// that date.
+func patchedDue(before, after *taskDocument) (*domain.Due, bool, error) {
+	datetimeChanged := !reflect.DeepEqual(before.DueDatetime, after.DueDatetime)
+	timezoneChanged := !reflect.DeepEqual(before.DueTimezone, after.DueTimezone)
+	if !datetimeChanged && !timezoneChanged && reflect.DeepEqual(before.DueDate, after.DueDate) {
+		return nil, false, nil
+	}
+
+	var date, datetime, timezone string
+	switch {
+	case after.DueDatetime != nil && (datetimeChanged || timezoneChanged):
+		datetime = *after.DueDatetime
+		if after.DueTimezone != nil {
+			timezone = *after.DueTimezone
+		}
+	case after.DueDate != nil:
+		date = *after.DueDate
+	}
+
+	due, err := domain.ParseDue(date, datetime, timezone)
+	if err != nil {
+		return nil, false, apperrors.NewValidationError(err.Error())
+	}
+	return due, true, nil
+}
+
+// taskDocumentValue renders the task the way the API does, as a decoded
+// JSON object
+func taskDocumentValue(task *domain.Task) (map[string]interface{}, error) {
+	data, err := json.Marshal(mapper.ToTaskResponse(task))
+	if err != nil {
+		return nil, err
+	}
+	var document map[string]interface{}
+	err = json.Unmarshal(data, &document)
+	return document, err
+}
+
+func decodeTaskDocument(document map[string]interface{}) (*taskDocument, error) {
+	data, err := json.Marshal(document)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to encode task", err)
+	}
+
+	var decoded taskDocument
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return nil, apperrors.NewValidationError(fmt.Sprintf("field %q must be of type %s", typeErr.Field, typeErr.Type))
+		}
+		return nil, apperrors.NewValidationError("invalid task document")
+	}
+	return &decoded, nil
+}
+
+func patchError(err error) error {
+	return apperrors.NewValidationError(err.Error())
+}
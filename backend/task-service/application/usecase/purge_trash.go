@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// orphanedAttachmentBatchSize bounds the attachments cleaned up per query
+const orphanedAttachmentBatchSize = 100
+
+type PurgeTrashUseCase struct {
+	taskRepo       domain.TaskRepository
+	attachmentRepo domain.AttachmentRepository
+	blobStore      domain.BlobStore
+	retention      time.Duration
+}
+
+// NewPurgeTrashUseCase creates a use case that permanently deletes tasks
+// that have been in the trash for longer than retention
+func NewPurgeTrashUseCase(
+	taskRepo domain.TaskRepository,
+	attachmentRepo domain.AttachmentRepository,
+	blobStore domain.BlobStore,
+	retention time.Duration,
+) *PurgeTrashUseCase {
+	return &PurgeTrashUseCase{
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+		retention:      retention,
+	}
+}
+
+// Execute purges the expired trash and returns the number of tasks removed.
+// Attachments of purged tasks and deleted comments are removed along with
+// their blobs. It is safe to run concurrently from several replicas.
+func (uc *PurgeTrashUseCase) Execute(ctx context.Context) (int64, error) {
+	purged, err := uc.taskRepo.PurgeDeleted(time.Now().Add(-uc.retention))
+	if err != nil {
+		return 0, apperrors.NewInternalError("failed to purge trash", err)
+	}
+
+	if err := uc.purgeOrphanedAttachments(ctx); err != nil {
+		return purged, err
+	}
+	return purged, nil
+}
+
+// purgeOrphanedAttachments deletes the blob of every attachment whose task
+// or comment is gone, then its record
+func (uc *PurgeTrashUseCase) purgeOrphanedAttachments(ctx context.Context) error {
+	for {
+		attachments, err := uc.attachmentRepo.GetOrphaned(orphanedAttachmentBatchSize)
+		if err != nil {
+			return apperrors.NewInternalError("failed to get orphaned attachments", err)
+		}
+
+		for _, attachment := range attachments {
+			if err := uc.blobStore.Delete(ctx, attachment.StorageKey); err != nil {
+				return apperrors.NewInternalError("failed to delete attachment content", err)
+			}
+			if err := uc.attachmentRepo.Delete(attachment.ID); err != nil {
+				return apperrors.NewInternalError("failed to delete attachment", err)
+			}
+		}
+
+		if len(attachments) < orphanedAttachmentBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+	"github.com/todoist/backend/task-service/domain/quickadd"
+)
+
+type QuickAddTaskUseCase struct {
+	transactor    domain.Transactor
+	projectLookup domain.ProjectLookup
+	createTaskUC  *CreateTaskUseCase
+}
+
+func NewQuickAddTaskUseCase(transactor domain.Transactor, projectLookup domain.ProjectLookup) *QuickAddTaskUseCase {
+	return &QuickAddTaskUseCase{
+		transactor:    transactor,
+		projectLookup: projectLookup,
+		createTaskUC:  NewCreateTaskUseCase(transactor),
+	}
+}
+
+// Execute creates a task from a line of quick add text, reading its due
+// date, recurrence, priority, project and labels out of the title. A
+// project that does not exist stays in the title.
+func (uc *QuickAddTaskUseCase) Execute(ctx context.Context, userID string, req dto.QuickAddTaskRequest) (*dto.QuickAddTaskResponse, error) {
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+	if strings.TrimSpace(req.Text) == "" {
+		return nil, apperrors.NewBadRequestError("text is required")
+	}
+
+	loc, err := time.LoadLocation(req.Timezone)
+	if err != nil {
+		return nil, apperrors.NewBadRequestError("invalid timezone")
+	}
+
+	result := quickadd.Parse(req.Text, time.Now().In(loc))
+
+	var projectID *string
+	if result.Project != "" {
+		ids, err := uc.projectLookup.FindProjectIDsByName(ctx, userID, result.Project)
+		if err != nil {
+			return nil, apperrors.NewInternalError("failed to resolve project", err)
+		}
+		if len(ids) == 0 {
+			result.Unextract(quickadd.SpanProject)
+		} else {
+			projectID = &ids[0]
+		}
+	}
+
+	title := result.Title()
+	if title == "" {
+		return nil, apperrors.NewBadRequestError("text must contain a title besides the extracted parts")
+	}
+
+	createReq := dto.CreateTaskRequest{
+		Title:     title,
+		ProjectID: projectID,
+		Labels:    result.Labels,
+	}
+	if result.PriorityLevel != 0 {
+		createReq.Priority = domain.PriorityForLevel(result.PriorityLevel)
+	}
+	setQuickAddDue(&createReq, result, req.Timezone)
+
+	var task *domain.Task
+	err = uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		var err error
+		task, err = uc.createTaskUC.create(repos, createReq, userID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toQuickAddResponse(task, createReq, result), nil
+}
+
+// setQuickAddDue copies the due date and recurrence quick add extracted
+// into req. timezone is the zone of the reference time, empty for UTC.
+func setQuickAddDue(req *dto.CreateTaskRequest, result *quickadd.Result, timezone string) {
+	if result.Due != nil && result.DueHasTime {
+		dueDatetime := result.Due.Format(time.RFC3339)
+		req.DueDatetime = &dueDatetime
+		if timezone != "" {
+			req.DueTimezone = &timezone
+		}
+	} else if result.Due != nil {
+		dueDate := result.Due.Format(domain.DueDateLayout)
+		req.DueDate = &dueDate
+	}
+	if result.Recurrence != "" {
+		req.Recurrence = &result.Recurrence
+	}
+}
+
+func toQuickAddResponse(task *domain.Task, req dto.CreateTaskRequest, result *quickadd.Result) *dto.QuickAddTaskResponse {
+	extracted := dto.QuickAddExtracted{
+		Title:       req.Title,
+		DueDate:     req.DueDate,
+		DueDatetime: req.DueDatetime,
+		Recurrence:  req.Recurrence,
+		ProjectID:   req.ProjectID,
+		Labels:      result.Labels,
+	}
+	if extracted.Labels == nil {
+		extracted.Labels = []string{}
+	}
+	if result.PriorityLevel != 0 {
+		extracted.Priority = &req.Priority
+	}
+	if result.Project != "" {
+		extracted.Project = &result.Project
+	}
+
+	spans := make([]dto.QuickAddSpan, 0, len(result.Spans))
+	for _, span := range result.Spans {
+		spans = append(spans, dto.QuickAddSpan{
+			Type:  string(span.Kind),
+			Start: span.Start,
+			End:   span.End,
+			Text:  span.Text,
+		})
+	}
+
+	return &dto.QuickAddTaskResponse{
+		Task:      mapper.ToTaskResponse(task),
+		Extracted: extracted,
+		Spans:     spans,
+	}
+}
@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type RemoveDependencyUseCase struct {
+	taskRepo       domain.TaskRepository
+	dependencyRepo domain.DependencyRepository
+}
+
+func NewRemoveDependencyUseCase(taskRepo domain.TaskRepository, dependencyRepo domain.DependencyRepository) *RemoveDependencyUseCase {
+	return &RemoveDependencyUseCase{
+		taskRepo:       taskRepo,
+		dependencyRepo: dependencyRepo,
+	}
+}
+
+func (uc *RemoveDependencyUseCase) Execute(ctx context.Context, taskID, blockedByID, userID string) error {
+	if _, err := getOwnedTask(uc.taskRepo, taskID, userID); err != nil {
+		return err
+	}
+
+	if blockedByID == "" {
+		return apperrors.NewBadRequestError("blocking task ID is required")
+	}
+
+	if _, err := uc.dependencyRepo.Get(taskID, blockedByID); err != nil {
+		return apperrors.NewNotFoundError("dependency not found")
+	}
+
+	if err := uc.dependencyRepo.Remove(taskID, blockedByID); err != nil {
+		return apperrors.NewInternalError("failed to remove dependency", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type ReopenTaskUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewReopenTaskUseCase(transactor domain.Transactor) *ReopenTaskUseCase {
+	return &ReopenTaskUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute moves a completed task back to pending. Reopening a subtask
+// reopens its completed ancestors as well. A non-nil ifMatch must equal the
+// task's current version.
+func (uc *ReopenTaskUseCase) Execute(ctx context.Context, taskID, userID string, ifMatch *int) (*dto.TaskResponse, error) {
+	var task *domain.Task
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		var err error
+		task, err = getOwnedTask(repos.Tasks, taskID, userID)
+		if err != nil {
+			return err
+		}
+		if err := checkTaskVersion(task, ifMatch); err != nil {
+			return err
+		}
+		if !task.IsCompleted() {
+			return apperrors.NewConflictError("task is not completed")
+		}
+
+		status := domain.TaskStatusPending
+		task, err = applyTaskChanges(repos, task, taskChanges{Status: &status})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToTaskResponse(task), nil
+}
@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type RestoreTaskUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewRestoreTaskUseCase(transactor domain.Transactor) *RestoreTaskUseCase {
+	return &RestoreTaskUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute takes a task out of the trash together with the subtasks deleted
+// with it. Trashed ancestors are restored as well so the task keeps its
+// place in the hierarchy.
+func (uc *RestoreTaskUseCase) Execute(ctx context.Context, taskID, userID string) (*dto.TaskResponse, error) {
+	// Validate inputs
+	if taskID == "" {
+		return nil, apperrors.NewBadRequestError("task ID is required")
+	}
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	var response *dto.TaskResponse
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		task, err := repos.Tasks.GetDeleted(taskID)
+		if err != nil {
+			return apperrors.NewNotFoundError("task not found in trash")
+		}
+
+		// Verify task belongs to user
+		if task.UserID != userID {
+			return apperrors.NewForbiddenError("access denied to this task")
+		}
+
+		restored, err := repos.Tasks.Restore(taskID)
+		if err != nil {
+			return apperrors.NewInternalError("failed to restore task", err)
+		}
+
+		now := time.Now()
+		for _, trashed := range restored {
+			t, err := repos.Tasks.GetByID(trashed.ID)
+			if err != nil {
+				return apperrors.NewInternalError("failed to get task", err)
+			}
+			// Reminders that came due in the trash are not fired late
+			if !t.IsCompleted() {
+				if err := rescheduleReminders(repos, t, now); err != nil {
+					return err
+				}
+			}
+			if err := recordTaskRestored(repos, trashed, t); err != nil {
+				return err
+			}
+		}
+
+		task, err = repos.Tasks.GetByID(taskID)
+		if err != nil {
+			return apperrors.NewInternalError("failed to get task", err)
+		}
+		response = mapper.ToTaskResponse(task)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type RevokeCalendarTokenUseCase struct {
+	feedRepo domain.CalendarFeedRepository
+}
+
+func NewRevokeCalendarTokenUseCase(feedRepo domain.CalendarFeedRepository) *RevokeCalendarTokenUseCase {
+	return &RevokeCalendarTokenUseCase{
+		feedRepo: feedRepo,
+	}
+}
+
+// Execute revokes the user's calendar feed token; subscriptions using it
+// stop working at once
+func (uc *RevokeCalendarTokenUseCase) Execute(ctx context.Context, userID string) error {
+	if userID == "" {
+		return apperrors.NewBadRequestError("user ID is required")
+	}
+
+	deleted, err := uc.feedRepo.Delete(userID)
+	if err != nil {
+		return apperrors.NewInternalError("failed to revoke calendar token", err)
+	}
+	if !deleted {
+		return apperrors.NewNotFoundError("calendar token not found")
+	}
+	return nil
+}
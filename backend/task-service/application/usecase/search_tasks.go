@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const (
+	defaultSearchResults = 20
+	maxSearchResults     = 100
+)
+
+type SearchTasksUseCase struct {
+	taskRepo      domain.TaskRepository
+	projectLookup domain.ProjectLookup
+}
+
+func NewSearchTasksUseCase(taskRepo domain.TaskRepository, projectLookup domain.ProjectLookup) *SearchTasksUseCase {
+	return &SearchTasksUseCase{
+		taskRepo:      taskRepo,
+		projectLookup: projectLookup,
+	}
+}
+
+// Execute returns the user's tasks matching the search words, best match
+// first, narrowed by the same filters as GetUserTasksUseCase
+func (uc *SearchTasksUseCase) Execute(ctx context.Context, userID string, req dto.SearchTasksRequest) ([]*dto.TaskSearchResultResponse, error) {
+	// Validate input
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	terms := domain.SearchTerms(req.Query)
+	if len(terms) == 0 {
+		return nil, apperrors.NewBadRequestError("search query is required")
+	}
+
+	query, err := buildFilteredQuery(ctx, uc.projectLookup, userID, req.TaskFilterParams)
+	if err != nil {
+		return nil, err
+	}
+	query.Limit, err = pageSize(req.Limit, defaultSearchResults, maxSearchResults)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := uc.taskRepo.Search(userID, terms, query)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to search tasks", err)
+	}
+
+	// Convert to response DTOs
+	responses := []*dto.TaskSearchResultResponse{}
+	for _, result := range results {
+		responses = append(responses, mapper.ToTaskSearchResultResponse(result))
+	}
+
+	return responses, nil
+}
@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type StartTimerUseCase struct {
+	taskRepo      domain.TaskRepository
+	timeEntryRepo domain.TimeEntryRepository
+}
+
+func NewStartTimerUseCase(taskRepo domain.TaskRepository, timeEntryRepo domain.TimeEntryRepository) *StartTimerUseCase {
+	return &StartTimerUseCase{
+		taskRepo:      taskRepo,
+		timeEntryRepo: timeEntryRepo,
+	}
+}
+
+// Execute starts tracking time on a task. A user runs one timer at a time,
+// so a running timer has to be stopped first.
+func (uc *StartTimerUseCase) Execute(ctx context.Context, taskID, userID string, req dto.StartTimerRequest) (*dto.TimeEntryResponse, error) {
+	task, err := getOwnedTask(uc.taskRepo, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	running, err := uc.timeEntryRepo.GetRunning(userID)
+	if err == nil {
+		return nil, timerRunningError(running)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NewInternalError("failed to get running timer", err)
+	}
+
+	now := time.Now()
+	entry := &domain.TimeEntry{
+		ID:        uuid.New().String(),
+		TaskID:    task.ID,
+		UserID:    userID,
+		StartedAt: now,
+		Note:      req.Note,
+		CreatedAt: now,
+	}
+
+	// Another request may have started a timer since the check
+	err = uc.timeEntryRepo.Create(entry)
+	if errors.Is(err, domain.ErrTimerRunning) {
+		running, _ := uc.timeEntryRepo.GetRunning(userID)
+		return nil, timerRunningError(running)
+	}
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to start timer", err)
+	}
+
+	return mapper.ToTimeEntryResponse(entry, now), nil
+}
+
+// timerRunningError reports the user's running timer, if it is known
+func timerRunningError(running *domain.TimeEntry) error {
+	if running == nil {
+		return apperrors.NewConflictError("a timer is already running, stop it first")
+	}
+	return apperrors.NewConflictError("a timer is already running on task " + running.TaskID + ", stop it first")
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type StopTimerUseCase struct {
+	taskRepo      domain.TaskRepository
+	timeEntryRepo domain.TimeEntryRepository
+}
+
+func NewStopTimerUseCase(taskRepo domain.TaskRepository, timeEntryRepo domain.TimeEntryRepository) *StopTimerUseCase {
+	return &StopTimerUseCase{
+		taskRepo:      taskRepo,
+		timeEntryRepo: timeEntryRepo,
+	}
+}
+
+// Execute stops the user's timer on a task and returns the finished entry
+func (uc *StopTimerUseCase) Execute(ctx context.Context, taskID, userID string) (*dto.TimeEntryResponse, error) {
+	if _, err := getOwnedTask(uc.taskRepo, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	entry, err := uc.timeEntryRepo.GetRunning(userID)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && entry.TaskID != taskID) {
+		return nil, apperrors.NewNotFoundError("no timer is running on this task")
+	}
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get running timer", err)
+	}
+
+	// A concurrent stop may have finished the entry since it was read
+	now := time.Now()
+	err = uc.timeEntryRepo.Stop(entry.ID, now)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, apperrors.NewNotFoundError("no timer is running on this task")
+	}
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to stop timer", err)
+	}
+	entry.StoppedAt = &now
+
+	return mapper.ToTimeEntryResponse(entry, now), nil
+}
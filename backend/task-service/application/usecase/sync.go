@@ -0,0 +1,247 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// Sync command types, each applied like the batch operation it maps to
+const (
+	SyncCommandTaskAdd    = "task_add"
+	SyncCommandTaskUpdate = "task_update"
+	SyncCommandTaskClose  = "task_close"
+	SyncCommandTaskReopen = "task_reopen"
+	SyncCommandTaskMove   = "task_move"
+	SyncCommandTaskDelete = "task_delete"
+)
+
+var syncCommandOps = map[string]string{
+	SyncCommandTaskAdd:    BatchOpCreate,
+	SyncCommandTaskUpdate: BatchOpUpdate,
+	SyncCommandTaskClose:  BatchOpComplete,
+	SyncCommandTaskReopen: BatchOpReopen,
+	SyncCommandTaskMove:   BatchOpMove,
+	SyncCommandTaskDelete: BatchOpDelete,
+}
+
+const (
+	maxSyncCommands = 100
+	maxTempIDLength = 64
+)
+
+// syncTaskRefArgs are the command args holding task IDs, which may be temp
+// IDs
+var syncTaskRefArgs = []string{"id", "parent_id", "before", "after"}
+
+// syncTaskRef is the part of a command's args that addresses a task
+type syncTaskRef struct {
+	ID      string `json:"id"`
+	Version *int   `json:"version"`
+	Force   bool   `json:"force"`
+}
+
+type SyncUseCase struct {
+	transactor domain.Transactor
+	syncRepo   domain.SyncRepository
+	batchUC    *BatchTasksUseCase
+}
+
+func NewSyncUseCase(transactor domain.Transactor, syncRepo domain.SyncRepository) *SyncUseCase {
+	return &SyncUseCase{
+		transactor: transactor,
+		syncRepo:   syncRepo,
+		batchUC:    NewBatchTasksUseCase(transactor),
+	}
+}
+
+// Execute applies the request's commands, each in a transaction of its own
+// and through the same code as its batch operation, then returns the tasks
+// changed and deleted since the request's sync token. A failed command is
+// reported in the response and does not stop the ones after it; a command
+// that was already applied is not applied again.
+func (uc *SyncUseCase) Execute(ctx context.Context, userID string, req dto.SyncRequest) (*dto.SyncResponse, error) {
+	// Validate inputs
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+	token := req.SyncToken
+	if token == "*" {
+		token = ""
+	}
+	if token != "" {
+		if _, err := strconv.ParseUint(token, 10, 64); err != nil {
+			return nil, apperrors.NewBadRequestError("invalid sync token")
+		}
+	}
+	if len(req.Commands) > maxSyncCommands {
+		return nil, apperrors.NewBadRequestError(fmt.Sprintf("a sync holds at most %d commands", maxSyncCommands))
+	}
+
+	statuses := make(map[string]*dto.SyncCommandStatus, len(req.Commands))
+	tempIDs := make(map[string]string)
+	for _, command := range req.Commands {
+		statuses[command.UUID] = uc.apply(ctx, userID, command, tempIDs)
+	}
+
+	// Read after the commands so the response includes their effects
+	changes, err := uc.syncRepo.GetChanges(userID, token)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get changes", err)
+	}
+
+	response := mapper.ToSyncResponse(changes, token == "")
+	response.SyncStatus = statuses
+	response.TempIDMapping = tempIDs
+	return response, nil
+}
+
+// apply applies one command and adds the task it created to tempIDs
+func (uc *SyncUseCase) apply(ctx context.Context, userID string, command dto.SyncCommand, tempIDs map[string]string) *dto.SyncCommandStatus {
+	opName, record, err := parseSyncCommand(userID, command)
+	if err != nil {
+		status, message := batchFailure(err)
+		return &dto.SyncCommandStatus{Status: status, Error: message}
+	}
+
+	var createdID string
+	err = uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		claimed, err := repos.SyncCommands.Claim(record)
+		if err != nil {
+			return apperrors.NewInternalError("failed to record command", err)
+		}
+		if !claimed {
+			previous, err := repos.SyncCommands.Get(userID, record.UUID)
+			if err != nil {
+				return apperrors.NewInternalError("failed to get command", err)
+			}
+			if previous.TaskID != nil {
+				createdID = *previous.TaskID
+			}
+			return nil
+		}
+
+		item, err := resolveSyncArgs(repos.SyncCommands, userID, opName, command.Args, tempIDs)
+		if err != nil {
+			return err
+		}
+		op, err := parseBatchOperation(item)
+		if err != nil {
+			return err
+		}
+		task, err := uc.batchUC.run(repos, userID, op)
+		if err != nil {
+			return err
+		}
+
+		if op.op == BatchOpCreate {
+			createdID = task.ID
+			if err := repos.SyncCommands.SetTaskID(userID, record.UUID, task.ID); err != nil {
+				return apperrors.NewInternalError("failed to record command", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		status, message := batchFailure(err)
+		return &dto.SyncCommandStatus{Status: status, Error: message}
+	}
+
+	if record.TempID != nil && createdID != "" {
+		tempIDs[*record.TempID] = createdID
+	}
+	if opName == BatchOpCreate {
+		return &dto.SyncCommandStatus{Status: http.StatusCreated}
+	}
+	return &dto.SyncCommandStatus{Status: http.StatusOK}
+}
+
+// parseSyncCommand validates a command and returns its batch operation and
+// the record claiming it
+func parseSyncCommand(userID string, command dto.SyncCommand) (string, *domain.SyncCommand, error) {
+	opName, ok := syncCommandOps[command.Type]
+	if !ok {
+		return "", nil, apperrors.NewBadRequestError(fmt.Sprintf("unknown command type %q", command.Type))
+	}
+	commandUUID, err := uuid.Parse(command.UUID)
+	if err != nil {
+		return "", nil, apperrors.NewBadRequestError("uuid must be a UUID")
+	}
+
+	record := &domain.SyncCommand{
+		UserID:    userID,
+		UUID:      commandUUID.String(),
+		CreatedAt: time.Now(),
+	}
+	if command.TempID != "" {
+		if opName != BatchOpCreate {
+			return "", nil, apperrors.NewBadRequestError("temp_id is only allowed on task_add")
+		}
+		if len(command.TempID) > maxTempIDLength {
+			return "", nil, apperrors.NewBadRequestError(fmt.Sprintf("temp_id must be at most %d characters", maxTempIDLength))
+		}
+		tempID := command.TempID
+		record.TempID = &tempID
+	}
+	return opName, record, nil
+}
+
+// resolveSyncArgs replaces temp IDs in a command's args with the IDs of the
+// tasks created for them, in this sync or an earlier one, and turns the
+// args into a batch operation
+func resolveSyncArgs(commands domain.SyncCommandRepository, userID, opName string, raw json.RawMessage, tempIDs map[string]string) (dto.BatchTaskOperation, error) {
+	item := dto.BatchTaskOperation{Op: opName}
+
+	args := map[string]interface{}{}
+	if len(raw) > 0 && string(raw) != "null" {
+		if err := json.Unmarshal(raw, &args); err != nil {
+			return item, apperrors.NewBadRequestError("args must be an object")
+		}
+	}
+
+	for _, name := range syncTaskRefArgs {
+		value, ok := args[name].(string)
+		if !ok || value == "" {
+			continue
+		}
+		if id, ok := tempIDs[value]; ok {
+			args[name] = id
+			continue
+		}
+		id, err := commands.ResolveTempID(userID, value)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return item, apperrors.NewInternalError("failed to resolve temp ID", err)
+		}
+		args[name] = id
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return item, apperrors.NewInternalError("failed to encode args", err)
+	}
+	var ref syncTaskRef
+	if err := json.Unmarshal(data, &ref); err != nil {
+		return item, apperrors.NewBadRequestError("invalid args")
+	}
+
+	item.TaskID = ref.ID
+	item.Version = ref.Version
+	item.Force = ref.Force
+	item.Data = data
+	return item, nil
+}
@@ -0,0 +1,65 @@
+package usecase
+
+import (
+	"errors"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// PreconditionFailedError reports an If-Match version that is no longer
+// current. Current is the task as it is now, for the client to merge with.
+type PreconditionFailedError struct {
+	Current *dto.TaskResponse
+}
+
+func (e *PreconditionFailedError) Error() string {
+	return "task version does not match"
+}
+
+// getOwnedTask loads a task and verifies that it belongs to the user, with
+// the same checks as GetTaskUseCase
+func getOwnedTask(taskRepo domain.TaskRepository, taskID, userID string) (*domain.Task, error) {
+	if taskID == "" {
+		return nil, apperrors.NewBadRequestError("task ID is required")
+	}
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+
+	task, err := taskRepo.GetByID(taskID)
+	if err != nil {
+		return nil, apperrors.NewNotFoundError("task not found")
+	}
+
+	if task.UserID != userID {
+		return nil, apperrors.NewForbiddenError("access denied to this task")
+	}
+
+	return task, nil
+}
+
+// checkTaskVersion enforces an If-Match precondition; a nil version matches
+// any task
+func checkTaskVersion(task *domain.Task, ifMatch *int) error {
+	if ifMatch != nil && *ifMatch != task.Version {
+		return &PreconditionFailedError{Current: mapper.ToTaskResponse(task)}
+	}
+	return nil
+}
+
+// saveTask updates a task, reporting a write that raced with another one
+// as a conflict
+func saveTask(taskRepo domain.TaskRepository, task *domain.Task) error {
+	err := taskRepo.Update(task)
+	if errors.Is(err, domain.ErrTaskVersionConflict) {
+		return apperrors.NewConflictError("task was modified by another request, please retry")
+	}
+	if err != nil {
+		return apperrors.NewInternalError("failed to update task", err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// taskChanges is a set of field updates for a task. Nil pointers and unset
+// flags leave a field alone; a set flag with a nil value clears the field.
+type taskChanges struct {
+	Title       *string
+	Description *string
+	Status      *string
+	Priority    *int
+
+	SetProject bool
+	ProjectID  *string
+
+	SetDue bool
+	Due    *domain.Due
+
+	// Recurrence replaces the recurrence rule; an empty string removes it
+	Recurrence *string
+
+	SetLabels bool
+	Labels    []string
+
+	// EstimatedMinutes replaces the estimate; zero removes it
+	EstimatedMinutes *int
+
+	// Force completes the task even while it waits on open blockers
+	Force bool
+}
+
+func sameProject(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// isEmpty reports whether the changes leave the task untouched
+func (c taskChanges) isEmpty() bool {
+	return c.Title == nil && c.Description == nil && c.Status == nil && c.Priority == nil &&
+		!c.SetProject && !c.SetDue && c.Recurrence == nil && !c.SetLabels && c.EstimatedMinutes == nil
+}
+
+// parseDue reads the due fields of a request, where nil counts as empty
+func parseDue(date, datetime, timezone *string) (*domain.Due, error) {
+	value := func(s *string) string {
+		if s == nil {
+			return ""
+		}
+		return *s
+	}
+	due, err := domain.ParseDue(value(date), value(datetime), value(timezone))
+	if err != nil {
+		return nil, apperrors.NewBadRequestError(err.Error())
+	}
+	return due, nil
+}
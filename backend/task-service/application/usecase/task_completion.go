@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// completeOccurrence builds the completion history entry for a task that is
+// being completed. A recurring task with occurrences left is rolled forward
+// to its next due date and kept open, which is reported by the returned bool.
+func completeOccurrence(task *domain.Task, now time.Time) (*domain.TaskCompletion, bool, error) {
+	completion := &domain.TaskCompletion{
+		ID:          uuid.New().String(),
+		TaskID:      task.ID,
+		UserID:      task.UserID,
+		DueDate:     task.DueDate,
+		CompletedAt: now,
+	}
+
+	if !task.IsRecurring() {
+		return completion, false, nil
+	}
+
+	advanced, err := task.AdvanceRecurrence(now)
+	if err != nil {
+		return nil, false, apperrors.NewInternalError("failed to compute next occurrence", err)
+	}
+	if advanced {
+		task.Reopen()
+	}
+
+	return completion, advanced, nil
+}
+
+// recordCompletionStats adds a completion to the user's productivity stats,
+// with its karma and the bonuses for the goals it reaches, and keeps both on
+// the completion so reopening the task can take them back. It runs before
+// the completion is stored.
+func recordCompletionStats(repos domain.Repositories, task *domain.Task, completion *domain.TaskCompletion) error {
+	stats, err := repos.Stats.Lock(domain.NewUserStats(completion.UserID, completion.CompletedAt))
+	if err != nil {
+		return apperrors.NewInternalError("failed to get stats", err)
+	}
+
+	loc := stats.Location()
+	day := domain.StatsDay(completion.CompletedAt, loc)
+	karma := domain.CompletionKarma(task, completion, loc)
+	dayCount, err := repos.Stats.AddCompletion(completion.UserID, day, karma)
+	if err != nil {
+		return apperrors.NewInternalError("failed to update stats", err)
+	}
+	weekCount, err := repos.Stats.CountCompleted(completion.UserID, domain.StatsWeek(day), day)
+	if err != nil {
+		return apperrors.NewInternalError("failed to update stats", err)
+	}
+
+	// Goals are reached once, by the completion that meets them
+	bonus := 0
+	if dayCount == stats.DailyGoal {
+		bonus += domain.KarmaDailyGoalBonus
+	}
+	if weekCount == stats.WeeklyGoal {
+		bonus += domain.KarmaWeeklyGoalBonus
+	}
+	if bonus > 0 {
+		if err := repos.Stats.AddKarma(completion.UserID, bonus); err != nil {
+			return apperrors.NewInternalError("failed to update stats", err)
+		}
+	}
+
+	completion.Karma = karma + bonus
+	completion.StatsDay = &day
+	return nil
+}
+
+// undoCompletion takes back the completion of a task being reopened, with
+// the karma and day count it added to the stats, so closing and reopening a
+// task repeatedly earns nothing. before is the task while still completed;
+// a task completed along with its parent has no completion of its own.
+func undoCompletion(repos domain.Repositories, before *domain.Task) error {
+	completion, err := repos.Completions.GetLatest(before.ID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return apperrors.NewInternalError("failed to get task completion", err)
+	}
+	if before.CompletedAt == nil || !completion.CompletedAt.Equal(*before.CompletedAt) {
+		return nil
+	}
+
+	if completion.StatsDay != nil {
+		if err := repos.Stats.RemoveCompletion(completion.UserID, *completion.StatsDay, completion.Karma); err != nil {
+			return apperrors.NewInternalError("failed to update stats", err)
+		}
+	}
+	if err := repos.Completions.Delete(completion.ID); err != nil {
+		return apperrors.NewInternalError("failed to undo task completion", err)
+	}
+	return nil
+}
+
+// checkOpenBlockers refuses to complete a task while it or one of its
+// subtasks, which complete along with it, waits on an open task outside
+// the subtree. subtree is the task followed by its descendants.
+func checkOpenBlockers(dependencyRepo domain.DependencyRepository, subtree []*domain.Task) error {
+	inSubtree := make(map[string]bool, len(subtree))
+	var ids []string
+	for _, task := range subtree {
+		inSubtree[task.ID] = true
+		if !task.IsCompleted() {
+			ids = append(ids, task.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	dependencies, err := dependencyRepo.OpenBlockers(ids)
+	if err != nil {
+		return apperrors.NewInternalError("failed to get blocking tasks", err)
+	}
+
+	blockers := make(map[string]bool)
+	for _, dependency := range dependencies {
+		if !inSubtree[dependency.BlockedByID] {
+			blockers[dependency.BlockedByID] = true
+		}
+	}
+	if len(blockers) > 0 {
+		return apperrors.NewConflictError(fmt.Sprintf(
+			"task is blocked by %d open task(s); pass force=true to complete it anyway", len(blockers)))
+	}
+	return nil
+}
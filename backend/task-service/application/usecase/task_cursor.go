@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// cursorPayload is the wire form of a domain.TaskCursor. Clients treat the
+// encoded value as opaque.
+type cursorPayload struct {
+	Sort        domain.TaskSort `json:"s"`
+	Descending  bool            `json:"d"`
+	ID          string          `json:"id"`
+	Priority    int             `json:"p,omitempty"`
+	DueDate     *time.Time      `json:"due,omitempty"`
+	CreatedAt   time.Time       `json:"c"`
+	Position    string          `json:"pos,omitempty"`
+	CompletedAt *time.Time      `json:"done,omitempty"`
+}
+
+func encodeCursor(cursor *domain.TaskCursor) string {
+	data, _ := json.Marshal(cursorPayload(*cursor))
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses a cursor and checks that it belongs to a listing with
+// the same sort and direction
+func decodeCursor(encoded string, sort domain.TaskSort, descending bool) (*domain.TaskCursor, error) {
+	invalid := apperrors.NewBadRequestError("invalid cursor")
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalid
+	}
+	var payload cursorPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.ID == "" {
+		return nil, invalid
+	}
+	if payload.Sort != sort || payload.Descending != descending {
+		return nil, apperrors.NewBadRequestError("cursor does not match the requested sort")
+	}
+
+	cursor := domain.TaskCursor(payload)
+	return &cursor, nil
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+	"github.com/todoist/backend/pkg/events"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// recordEvent writes a domain event to the transactional outbox. It is
+// published by the outbox relay once the surrounding transaction commits,
+// routed by its event type.
+func recordEvent(outbox domain.OutboxRepository, event interface{}) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return apperrors.NewInternalError("failed to encode event", err)
+	}
+
+	var base events.BaseEvent
+	if err := json.Unmarshal(payload, &base); err != nil {
+		return apperrors.NewInternalError("failed to encode event", err)
+	}
+
+	message := &domain.OutboxMessage{
+		ID:         base.EventID.String(),
+		RoutingKey: base.EventType,
+		Payload:    payload,
+		CreatedAt:  base.Timestamp,
+	}
+	if err := outbox.Add(message); err != nil {
+		return apperrors.NewInternalError("failed to record event", err)
+	}
+	return nil
+}
+
+func recordTaskCreated(repos domain.Repositories, task *domain.Task) error {
+	err := recordEvent(repos.Outbox, events.NewTaskCreated(
+		parseUUID(task.UserID), parseUUID(task.ID), projectUUID(task),
+		task.Title, task.Description, task.Priority, task.DueDate,
+	))
+	if err != nil {
+		return err
+	}
+	return recordActivity(repos.Activity, domain.ActivityCreated, task, domain.DiffTasks(nil, task))
+}
+
+// recordTaskUpdated records TaskUpdated and an activity entry with the
+// fields that differ between the two versions of a task; nothing is
+// recorded when none do
+func recordTaskUpdated(repos domain.Repositories, before, after *domain.Task) error {
+	return recordTaskChange(repos, domain.ActivityUpdated, before, after)
+}
+
+// recordTaskCompleted records TaskCompleted for a completed occurrence,
+// followed by the changed fields like recordTaskUpdated. The activity entry
+// is a completion rather than an update.
+func recordTaskCompleted(repos domain.Repositories, before, after *domain.Task, completedAt time.Time) error {
+	err := recordEvent(repos.Outbox, events.NewTaskCompleted(parseUUID(after.UserID), parseUUID(after.ID), projectUUID(after), completedAt))
+	if err != nil {
+		return err
+	}
+	return recordTaskChange(repos, domain.ActivityCompleted, before, after)
+}
+
+func recordTaskChange(repos domain.Repositories, action string, before, after *domain.Task) error {
+	diff := domain.DiffTasks(before, after)
+	if len(diff) == 0 {
+		return nil
+	}
+
+	changes := make(map[string]interface{}, len(diff))
+	for field, change := range diff {
+		changes[field] = map[string]interface{}{"old": change.Old, "new": change.New}
+	}
+	err := recordEvent(repos.Outbox, events.NewTaskUpdated(parseUUID(after.UserID), parseUUID(after.ID), projectUUID(after), changes))
+	if err != nil {
+		return err
+	}
+	return recordActivity(repos.Activity, action, after, diff)
+}
+
+// recordTaskDeleted records the move of a task to the trash at deletedAt
+func recordTaskDeleted(repos domain.Repositories, task *domain.Task, deletedAt time.Time) error {
+	if err := recordEvent(repos.Outbox, events.NewTaskDeleted(parseUUID(task.UserID), parseUUID(task.ID), projectUUID(task))); err != nil {
+		return err
+	}
+
+	trashed := task.Clone()
+	trashed.DeletedAt = &deletedAt
+	return recordActivity(repos.Activity, domain.ActivityDeleted, task, domain.DiffTasks(task, trashed))
+}
+
+// recordTaskRestored records the restore of a task, with what changed since
+// it was in the trash
+func recordTaskRestored(repos domain.Repositories, trashed, restored *domain.Task) error {
+	if err := recordEvent(repos.Outbox, events.NewTaskRestored(parseUUID(restored.UserID), parseUUID(restored.ID), projectUUID(restored))); err != nil {
+		return err
+	}
+	return recordActivity(repos.Activity, domain.ActivityRestored, restored, domain.DiffTasks(trashed, restored))
+}
+
+// recordActivity appends an entry to the activity history of a task. It is
+// written in the same transaction as the change and its event.
+func recordActivity(activityRepo domain.ActivityRepository, action string, task *domain.Task, changes map[string]domain.FieldChange) error {
+	activity := &domain.Activity{
+		ID:        uuid.New().String(),
+		TaskID:    task.ID,
+		UserID:    task.UserID,
+		Action:    action,
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	}
+	if err := activityRepo.Add(activity); err != nil {
+		return apperrors.NewInternalError("failed to record activity", err)
+	}
+	return nil
+}
+
+// recordSubtreeChanges records events and activity for the descendants of a task that a
+// write changed, comparing them with snapshots taken before the write.
+// Descendants that became completed also get TaskCompleted.
+func recordSubtreeChanges(repos domain.Repositories, rootID string, before []*domain.Task, now time.Time) error {
+	snapshots := make(map[string]*domain.Task, len(before))
+	for _, task := range before {
+		snapshots[task.ID] = task
+	}
+
+	subtree, err := repos.Tasks.GetSubtree(rootID)
+	if err != nil {
+		return apperrors.NewInternalError("failed to get subtasks", err)
+	}
+
+	for _, task := range subtree {
+		snapshot, ok := snapshots[task.ID]
+		if task.ID == rootID || !ok {
+			continue
+		}
+		if !snapshot.IsCompleted() && task.IsCompleted() {
+			if err := recordTaskCompleted(repos, snapshot, task, now); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := recordTaskUpdated(repos, snapshot, task); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// projectUUID is the project of a task for events, the nil UUID for tasks
+// in the inbox
+func projectUUID(task *domain.Task) uuid.UUID {
+	if task.ProjectID == nil {
+		return uuid.Nil
+	}
+	return parseUUID(*task.ProjectID)
+}
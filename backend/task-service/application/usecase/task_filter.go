@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+	"github.com/todoist/backend/task-service/domain/filter"
+)
+
+// buildFilteredQuery turns filter parameters into a repository query,
+// parsing the filter expression and resolving the project names it
+// references. An invalid expression is returned as a *filter.ParseError.
+func buildFilteredQuery(ctx context.Context, projectLookup domain.ProjectLookup, userID string, params dto.TaskFilterParams) (domain.TaskQuery, error) {
+	// Relative dates such as "today" and "overdue" follow the user's day
+	loc, err := time.LoadLocation(params.Timezone)
+	if err != nil {
+		return domain.TaskQuery{}, apperrors.NewBadRequestError("invalid timezone")
+	}
+
+	query := domain.TaskQuery{
+		Status:    params.Status,
+		Priority:  params.Priority,
+		ProjectID: params.ProjectID,
+		Labels:    params.Labels,
+		Blocked:   params.Blocked,
+		Now:       time.Now().In(loc),
+	}
+
+	if strings.TrimSpace(params.Filter) == "" {
+		return query, nil
+	}
+
+	expr, err := filter.Parse(params.Filter)
+	if err != nil {
+		return query, err
+	}
+
+	var lookupErr error
+	filter.Walk(expr, func(node filter.Expr) {
+		project, ok := node.(*filter.Project)
+		if !ok || lookupErr != nil {
+			return
+		}
+		project.IDs, lookupErr = projectLookup.FindProjectIDsByName(ctx, userID, project.Name)
+	})
+	if lookupErr != nil {
+		return query, apperrors.NewInternalError("failed to resolve projects", lookupErr)
+	}
+
+	query.Filter = expr
+	return query, nil
+}
+
+// pageSize validates a requested page size, applying the default when none
+// was given and capping it at max
+func pageSize(requested, defaultSize, max int) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, apperrors.NewBadRequestError("limit must be positive")
+	case requested == 0:
+		return defaultSize, nil
+	case requested > max:
+		return max, nil
+	}
+	return requested, nil
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// applyHierarchyStatusRules propagates a status change of task through the
+// hierarchy: completing a task completes its subtree, and reopening a
+// subtask reopens its completed ancestors. Reminders wait while their task
+// is completed. Events for the subtree are left to recordSubtreeChanges;
+// reopened ancestors are recorded here.
+func applyHierarchyStatusRules(repos domain.Repositories, task *domain.Task, wasCompleted bool, now time.Time) error {
+	switch {
+	case !wasCompleted && task.IsCompleted():
+		if err := repos.Tasks.SetSubtreeStatus(task.ID, domain.TaskStatusCompleted); err != nil {
+			return apperrors.NewInternalError("failed to complete subtasks", err)
+		}
+		if err := repos.Reminders.UnscheduleSubtree(task.ID); err != nil {
+			return apperrors.NewInternalError("failed to unschedule reminders", err)
+		}
+	case wasCompleted && !task.IsCompleted() && task.IsSubtask():
+		ancestors, err := repos.Tasks.GetAncestors(task.ID)
+		if err != nil {
+			return apperrors.NewInternalError("failed to get parent tasks", err)
+		}
+		for _, ancestor := range ancestors {
+			if !ancestor.IsCompleted() {
+				continue
+			}
+			before := ancestor.Clone()
+			ancestor.Reopen()
+			if err := saveTask(repos.Tasks, ancestor); err != nil {
+				return err
+			}
+			if err := undoCompletion(repos, before); err != nil {
+				return err
+			}
+			if err := rescheduleReminders(repos, ancestor, now); err != nil {
+				return err
+			}
+			if err := recordTaskUpdated(repos, before, ancestor); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// resolveLabels looks up the user's labels by name, creating the ones that
+// do not exist yet, and returns them in the order given
+func resolveLabels(labelRepo domain.LabelRepository, userID string, names []string) ([]*domain.Label, error) {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name, err := domain.NormalizeLabelName(name)
+		if err != nil {
+			return nil, apperrors.NewBadRequestError(err.Error())
+		}
+		if seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		normalized = append(normalized, name)
+	}
+	if len(normalized) == 0 {
+		return nil, nil
+	}
+
+	existing, err := labelRepo.GetByNames(userID, normalized)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get labels", err)
+	}
+	byName := make(map[string]*domain.Label, len(existing))
+	for _, label := range existing {
+		byName[strings.ToLower(label.Name)] = label
+	}
+
+	labels := make([]*domain.Label, 0, len(normalized))
+	for _, name := range normalized {
+		label, ok := byName[strings.ToLower(name)]
+		if !ok {
+			now := time.Now()
+			label = &domain.Label{
+				ID:        uuid.New().String(),
+				UserID:    userID,
+				Name:      name,
+				Color:     domain.DefaultLabelColor,
+				CreatedAt: now,
+				UpdatedAt: now,
+			}
+			if err := labelRepo.Create(label); err != nil {
+				return nil, apperrors.NewInternalError("failed to create label", err)
+			}
+		}
+		labels = append(labels, label)
+	}
+
+	return labels, nil
+}
+
+// setTaskLabels replaces the labels of a task with the named ones
+func setTaskLabels(labelRepo domain.LabelRepository, task *domain.Task, names []string) error {
+	labels, err := resolveLabels(labelRepo, task.UserID, names)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]string, len(labels))
+	task.Labels = make([]string, len(labels))
+	for i, label := range labels {
+		ids[i] = label.ID
+		task.Labels[i] = label.Name
+	}
+
+	if err := labelRepo.SetTaskLabels(task.ID, ids); err != nil {
+		return apperrors.NewInternalError("failed to update task labels", err)
+	}
+	return nil
+}
+
+// relabelTasks runs change, a rename or deletion of a label, and records
+// TaskUpdated with an activity entry for every task carrying the label,
+// whose version it bumps
+func relabelTasks(repos domain.Repositories, labelID string, change func() error) error {
+	ids, err := repos.Labels.GetTaskIDs(labelID)
+	if err != nil {
+		return apperrors.NewInternalError("failed to get labelled tasks", err)
+	}
+	before := make([]*domain.Task, 0, len(ids))
+	for _, id := range ids {
+		task, err := repos.Tasks.GetByID(id)
+		if err != nil {
+			return apperrors.NewInternalError("failed to get task", err)
+		}
+		before = append(before, task)
+	}
+
+	// Touch the tasks first, a deleted label no longer links to them
+	if err := repos.Labels.TouchTasks(labelID, time.Now()); err != nil {
+		return apperrors.NewInternalError("failed to update labelled tasks", err)
+	}
+	if err := change(); err != nil {
+		return err
+	}
+
+	for _, task := range before {
+		after, err := repos.Tasks.GetByID(task.ID)
+		if err != nil {
+			return apperrors.NewInternalError("failed to get task", err)
+		}
+		if err := recordTaskUpdated(repos, task, after); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"sort"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// lastPosition returns a rank after every task in the project or inbox
+func lastPosition(taskRepo domain.TaskRepository, userID string, projectID *string) (string, error) {
+	last, err := taskRepo.LastPosition(userID, projectID)
+	if err != nil {
+		return "", apperrors.NewInternalError("failed to get task position", err)
+	}
+	return rankBetween(last, "")
+}
+
+// positionNextTo returns a rank right before or right after the anchor in
+// its project or inbox
+func positionNextTo(taskRepo domain.TaskRepository, anchor *domain.Task, after bool) (string, error) {
+	if after {
+		next, err := taskRepo.PositionAfter(anchor.UserID, anchor.ProjectID, anchor.Position)
+		if err != nil {
+			return "", apperrors.NewInternalError("failed to get task position", err)
+		}
+		return rankBetween(anchor.Position, next)
+	}
+
+	previous, err := taskRepo.PositionBefore(anchor.UserID, anchor.ProjectID, anchor.Position)
+	if err != nil {
+		return "", apperrors.NewInternalError("failed to get task position", err)
+	}
+	return rankBetween(previous, anchor.Position)
+}
+
+// appendSubtree ranks the tasks of a subtree that moved to another project
+// after the tasks already there, keeping their relative order
+func appendSubtree(taskRepo domain.TaskRepository, userID string, projectID *string, subtree []*domain.Task) error {
+	ordered := append([]*domain.Task{}, subtree...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Position < ordered[j].Position
+	})
+
+	last, err := taskRepo.LastPosition(userID, projectID)
+	if err != nil {
+		return apperrors.NewInternalError("failed to get task position", err)
+	}
+	ranks, err := domain.RanksAfter(last, len(ordered))
+	if err != nil {
+		return apperrors.NewInternalError("failed to rank tasks", err)
+	}
+
+	for i, task := range ordered {
+		if err := taskRepo.SetPosition(task.ID, ranks[i]); err != nil {
+			return apperrors.NewInternalError("failed to update task position", err)
+		}
+	}
+	return nil
+}
+
+func rankBetween(before, after string) (string, error) {
+	rank, err := domain.RankBetween(before, after)
+	if err != nil {
+		return "", apperrors.NewInternalError("failed to rank task", err)
+	}
+	return rank, nil
+}
@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type UpdateCommentUseCase struct {
+	taskRepo    domain.TaskRepository
+	commentRepo domain.CommentRepository
+}
+
+func NewUpdateCommentUseCase(taskRepo domain.TaskRepository, commentRepo domain.CommentRepository) *UpdateCommentUseCase {
+	return &UpdateCommentUseCase{
+		taskRepo:    taskRepo,
+		commentRepo: commentRepo,
+	}
+}
+
+func (uc *UpdateCommentUseCase) Execute(ctx context.Context, taskID, commentID, userID string, req dto.UpdateCommentRequest) (*dto.CommentResponse, error) {
+	if req.Content == "" {
+		return nil, apperrors.NewBadRequestError("content is required")
+	}
+
+	comment, err := getTaskComment(uc.taskRepo, uc.commentRepo, taskID, commentID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	comment.Content = req.Content
+	if err := uc.commentRepo.Update(comment); err != nil {
+		return nil, apperrors.NewInternalError("failed to update comment", err)
+	}
+
+	return mapper.ToCommentResponse(comment), nil
+}
+
+// getTaskComment loads a comment of a task the user owns. Only the author
+// may change a comment.
+func getTaskComment(taskRepo domain.TaskRepository, commentRepo domain.CommentRepository, taskID, commentID, userID string) (*domain.Comment, error) {
+	if _, err := getOwnedTask(taskRepo, taskID, userID); err != nil {
+		return nil, err
+	}
+
+	if commentID == "" {
+		return nil, apperrors.NewBadRequestError("comment ID is required")
+	}
+
+	comment, err := commentRepo.GetByID(commentID)
+	if err != nil || comment.TaskID != taskID {
+		return nil, apperrors.NewNotFoundError("comment not found")
+	}
+
+	if comment.UserID != userID {
+		return nil, apperrors.NewForbiddenError("access denied to this comment")
+	}
+
+	return comment, nil
+}
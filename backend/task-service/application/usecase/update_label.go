@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/mapper"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type UpdateLabelUseCase struct {
+	transactor domain.Transactor
+}
+
+func NewUpdateLabelUseCase(transactor domain.Transactor) *UpdateLabelUseCase {
+	return &UpdateLabelUseCase{
+		transactor: transactor,
+	}
+}
+
+// Execute renames or recolors a label. Tasks reference labels by ID, so a
+// rename is reflected on every task that uses the label, and recorded as an
+// update of each of them.
+func (uc *UpdateLabelUseCase) Execute(ctx context.Context, labelID, userID string, req dto.UpdateLabelRequest) (*dto.LabelResponse, error) {
+	var label *domain.Label
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		var err error
+		label, err = getOwnedLabel(repos.Labels, labelID, userID)
+		if err != nil {
+			return err
+		}
+
+		renamed := false
+		if req.Name != "" {
+			name, err := domain.NormalizeLabelName(req.Name)
+			if err != nil {
+				return apperrors.NewBadRequestError(err.Error())
+			}
+
+			if !strings.EqualFold(name, label.Name) {
+				existing, err := repos.Labels.GetByNames(userID, []string{name})
+				if err != nil {
+					return apperrors.NewInternalError("failed to get labels", err)
+				}
+				if len(existing) > 0 {
+					return apperrors.NewConflictError("a label with this name already exists")
+				}
+			}
+			renamed = name != label.Name
+			label.Name = name
+		}
+		if req.Color != "" {
+			label.Color = req.Color
+		}
+
+		update := func() error {
+			if err := repos.Labels.Update(label); err != nil {
+				return apperrors.NewInternalError("failed to update label", err)
+			}
+			return nil
+		}
+		if !renamed {
+			return update()
+		}
+		return relabelTasks(repos, label.ID, update)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapper.ToLabelResponse(label), nil
+}
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type UpdateStatsGoalsUseCase struct {
+	transactor domain.Transactor
+	statsRepo  domain.StatsRepository
+}
+
+func NewUpdateStatsGoalsUseCase(transactor domain.Transactor, statsRepo domain.StatsRepository) *UpdateStatsGoalsUseCase {
+	return &UpdateStatsGoalsUseCase{
+		transactor: transactor,
+		statsRepo:  statsRepo,
+	}
+}
+
+// Execute changes the user's goals and time zone and returns the stats
+// measured against them. Completions already counted keep their day.
+func (uc *UpdateStatsGoalsUseCase) Execute(ctx context.Context, userID string, req dto.UpdateStatsGoalsRequest) (*dto.StatsResponse, error) {
+	// Validate inputs
+	if userID == "" {
+		return nil, apperrors.NewBadRequestError("user ID is required")
+	}
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil {
+			return nil, apperrors.NewBadRequestError("invalid timezone")
+		}
+	}
+
+	now := time.Now()
+	var stats *domain.UserStats
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		var err error
+		stats, err = repos.Stats.Lock(domain.NewUserStats(userID, now))
+		if err != nil {
+			return apperrors.NewInternalError("failed to get stats", err)
+		}
+
+		daily, weekly := stats.DailyGoal, stats.WeeklyGoal
+		if req.DailyGoal != nil {
+			daily = *req.DailyGoal
+		}
+		if req.WeeklyGoal != nil {
+			weekly = *req.WeeklyGoal
+		}
+		if err := stats.SetGoals(daily, weekly); err != nil {
+			return apperrors.NewBadRequestError(err.Error())
+		}
+		if req.Timezone != nil {
+			stats.Timezone = *req.Timezone
+		}
+		stats.UpdatedAt = now
+
+		if err := repos.Stats.SaveGoals(stats); err != nil {
+			return apperrors.NewInternalError("failed to update goals", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildStatsResponse(uc.statsRepo, stats, now, defaultStatsDays, defaultStatsWeeks)
+}
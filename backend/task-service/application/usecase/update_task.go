@@ -2,25 +2,28 @@ package usecase
 
 import (
 	"context"
+	"reflect"
 	"time"
 
+	apperrors "github.com/todoist/backend/pkg/errors"
 	"github.com/todoist/backend/task-service/application/dto"
 	"github.com/todoist/backend/task-service/application/mapper"
 	"github.com/todoist/backend/task-service/domain"
-	apperrors "github.com/todoist/backend/pkg/errors"
 )
 
 type UpdateTaskUseCase struct {
-	taskRepo domain.TaskRepository
+	transactor domain.Transactor
 }
 
-func NewUpdateTaskUseCase(taskRepo domain.TaskRepository) *UpdateTaskUseCase {
+func NewUpdateTaskUseCase(transactor domain.Transactor) *UpdateTaskUseCase {
 	return &UpdateTaskUseCase{
-		taskRepo: taskRepo,
+		transactor: transactor,
 	}
 }
 
-func (uc *UpdateTaskUseCase) Execute(ctx context.Context, taskID, userID string, req dto.UpdateTaskRequest) (*dto.TaskResponse, error) {
+// Execute updates a task. A non-nil ifMatch must equal the task's current
+// version.
+func (uc *UpdateTaskUseCase) Execute(ctx context.Context, taskID, userID string, req dto.UpdateTaskRequest, ifMatch *int) (*dto.TaskResponse, error) {
 	// Validate inputs
 	if taskID == "" {
 		return nil, apperrors.NewBadRequestError("task ID is required")
@@ -29,53 +32,233 @@ func (uc *UpdateTaskUseCase) Execute(ctx context.Context, taskID, userID string,
 		return nil, apperrors.NewBadRequestError("user ID is required")
 	}
 
-	// Get existing task
-	task, err := uc.taskRepo.GetByID(taskID)
+	var task *domain.Task
+	err := uc.transactor.WithinTransaction(ctx, func(repos domain.Repositories) error {
+		var err error
+		task, err = uc.apply(repos, taskID, userID, req, ifMatch)
+		return err
+	})
 	if err != nil {
-		return nil, apperrors.NewNotFoundError("task not found")
+		return nil, err
 	}
 
-	// Verify task belongs to user
-	if task.UserID != userID {
-		return nil, apperrors.NewForbiddenError("access denied to this task")
+	// Return response DTO
+	return mapper.ToTaskResponse(task), nil
+}
+
+// apply performs the update inside a transaction and records its events
+func (uc *UpdateTaskUseCase) apply(repos domain.Repositories, taskID, userID string, req dto.UpdateTaskRequest, ifMatch *int) (*domain.Task, error) {
+	changes, err := changesFromUpdateRequest(req)
+	if err != nil {
+		return nil, err
 	}
 
-	// Update fields if provided
+	task, err := getOwnedTask(repos.Tasks, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkTaskVersion(task, ifMatch); err != nil {
+		return nil, err
+	}
+
+	return applyTaskChanges(repos, task, changes)
+}
+
+// changesFromUpdateRequest interprets a PUT request, where zero values mean
+// "not provided"
+func changesFromUpdateRequest(req dto.UpdateTaskRequest) (taskChanges, error) {
+	var changes taskChanges
 	if req.Title != "" {
-		task.Title = req.Title
+		changes.Title = &req.Title
 	}
 	if req.Description != "" {
-		task.Description = req.Description
+		changes.Description = &req.Description
 	}
 	if req.Status != "" {
-		task.Status = req.Status
+		changes.Status = &req.Status
 	}
 	if req.Priority != 0 {
-		task.Priority = req.Priority
+		changes.Priority = &req.Priority
 	}
 	if req.ProjectID != nil {
-		task.ProjectID = req.ProjectID
+		changes.SetProject = true
+		changes.ProjectID = req.ProjectID
+	}
+	if req.DueDate != nil || req.DueDatetime != nil || req.DueTimezone != nil {
+		due, err := parseDue(req.DueDate, req.DueDatetime, req.DueTimezone)
+		if err != nil {
+			return changes, err
+		}
+		changes.SetDue = true
+		changes.Due = due
+	}
+	changes.Recurrence = req.Recurrence
+	if req.Labels != nil {
+		changes.SetLabels = true
+		changes.Labels = req.Labels
+	}
+	changes.EstimatedMinutes = req.EstimatedMinutes
+	changes.Force = req.Force
+	return changes, nil
+}
+
+// applyTaskChanges updates an owned task inside a transaction, applying the
+// completion and hierarchy rules, and records the resulting events
+func applyTaskChanges(repos domain.Repositories, task *domain.Task, changes taskChanges) (*domain.Task, error) {
+	// Snapshot the task and its subtree to describe the changes in events
+	before := task.Clone()
+	subtree, err := repos.Tasks.GetSubtree(task.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get subtasks", err)
+	}
+
+	wasCompleted := task.IsCompleted()
+	projectChanged := false
+	now := time.Now()
+
+	// Update fields if provided
+	if changes.Title != nil {
+		task.Title = *changes.Title
+	}
+	if changes.Description != nil {
+		task.Description = *changes.Description
+	}
+	if changes.Status != nil {
+		if err := task.SetStatus(*changes.Status, now); err != nil {
+			return nil, apperrors.NewBadRequestError(err.Error())
+		}
+	}
+	if changes.Priority != nil {
+		task.Priority = *changes.Priority
+	}
+	if changes.SetProject && !sameProject(task.ProjectID, changes.ProjectID) {
+		if task.IsSubtask() {
+			return nil, apperrors.NewBadRequestError("a subtask always belongs to its parent's project")
+		}
+		task.ProjectID = changes.ProjectID
+		projectChanged = true
+	}
+	if changes.SetDue {
+		task.SetDue(changes.Due)
+	}
+
+	if changes.EstimatedMinutes != nil {
+		if err := task.SetEstimate(*changes.EstimatedMinutes); err != nil {
+			return nil, apperrors.NewBadRequestError(err.Error())
+		}
+	}
+
+	// Update recurrence if provided; an empty string makes the task one-off
+	if changes.Recurrence != nil {
+		if err := task.SetRecurrence(*changes.Recurrence, now); err != nil {
+			return nil, apperrors.NewBadRequestError(err.Error())
+		}
 	}
-	if req.DueDate != nil {
-		if *req.DueDate != "" {
-			dueDate, err := time.Parse(time.RFC3339, *req.DueDate)
-			if err != nil {
-				return nil, apperrors.NewBadRequestError("invalid due_date format, should be RFC3339")
+
+	// Record the completion; recurring tasks roll forward instead of closing
+	var completion *domain.TaskCompletion
+	rolledForward := false
+	if !wasCompleted && task.IsCompleted() {
+		if !changes.Force {
+			if err := checkOpenBlockers(repos.Dependencies, subtree); err != nil {
+				return nil, err
 			}
-			task.DueDate = &dueDate
-		} else {
-			task.DueDate = nil
+		}
+		completion, rolledForward, err = completeOccurrence(task, now)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	// Update timestamp
-	task.UpdatedAt = time.Now()
+	task.UpdatedAt = now
 
 	// Update in repository
-	if err := uc.taskRepo.Update(task); err != nil {
-		return nil, apperrors.NewInternalError("failed to update task", err)
+	if err := saveTask(repos.Tasks, task); err != nil {
+		return nil, err
 	}
 
-	// Return response DTO
-	return mapper.ToTaskResponse(task), nil
+	// Replace labels if provided; an empty list removes all labels
+	if changes.SetLabels {
+		if err := setTaskLabels(repos.Labels, task, changes.Labels); err != nil {
+			return nil, err
+		}
+	}
+
+	// Keep the subtree in the task's project
+	if projectChanged {
+		if err := repos.Tasks.MoveSubtree(task.ID, task.ParentID, task.ProjectID); err != nil {
+			return nil, apperrors.NewInternalError("failed to move subtasks", err)
+		}
+		if err := appendSubtree(repos.Tasks, task.UserID, task.ProjectID, subtree); err != nil {
+			return nil, err
+		}
+	}
+
+	if completion != nil {
+		if err := recordCompletionStats(repos, task, completion); err != nil {
+			return nil, err
+		}
+		if err := repos.Completions.Create(completion); err != nil {
+			return nil, apperrors.NewInternalError("failed to record task completion", err)
+		}
+	}
+	if wasCompleted && !task.IsCompleted() {
+		if err := undoCompletion(repos, before); err != nil {
+			return nil, err
+		}
+	}
+
+	// A new occurrence starts with all of its subtasks open again
+	if rolledForward {
+		if err := repos.Tasks.SetSubtreeStatus(task.ID, domain.TaskStatusPending); err != nil {
+			return nil, apperrors.NewInternalError("failed to reopen subtasks", err)
+		}
+		for _, descendant := range subtree[1:] {
+			if !descendant.IsCompleted() {
+				continue
+			}
+			if err := rescheduleReminders(repos, descendant, now); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := applyHierarchyStatusRules(repos, task, wasCompleted, now); err != nil {
+		return nil, err
+	}
+
+	// Reload, since subtree writes bump the version as well
+	updated, err := repos.Tasks.GetByID(task.ID)
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to get task", err)
+	}
+
+	// Relative reminders follow the due date, including a rolled forward
+	// one, and reopening the task schedules the reminders still ahead
+	if !updated.IsCompleted() && (wasCompleted || !reflect.DeepEqual(before.Due(), updated.Due())) {
+		if err := rescheduleReminders(repos, updated, now); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := recordUpdateEvents(repos, before, subtree, updated, completion, now); err != nil {
+		return nil, err
+	}
+
+	return updated, nil
+}
+
+// recordUpdateEvents records TaskCompleted when an occurrence of the task was
+// completed, TaskUpdated with the fields that changed, and the events of
+// descendants affected by hierarchy rules, each with its activity entry
+func recordUpdateEvents(repos domain.Repositories, before *domain.Task, subtree []*domain.Task, task *domain.Task, completion *domain.TaskCompletion, now time.Time) error {
+	if completion != nil {
+		if err := recordTaskCompleted(repos, before, task, completion.CompletedAt); err != nil {
+			return err
+		}
+	} else if err := recordTaskUpdated(repos, before, task); err != nil {
+		return err
+	}
+	return recordSubtreeChanges(repos, task.ID, subtree, now)
 }
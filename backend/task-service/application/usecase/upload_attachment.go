@@ -0,0 +1,198 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	apperrors "github.com/todoist/backend/pkg/errors"
+
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// maxFileNameLength bounds stored file names, in runes
+const maxFileNameLength = 255
+
+// sniffLength is how much of a file content type detection looks at
+const sniffLength = 512
+
+type UploadAttachmentUseCase struct {
+	taskRepo       domain.TaskRepository
+	commentRepo    domain.CommentRepository
+	attachmentRepo domain.AttachmentRepository
+	blobStore      domain.BlobStore
+	maxSize        int64
+	urlExpiry      time.Duration
+}
+
+// NewUploadAttachmentUseCase creates a use case that stores files of up to
+// maxSize bytes, answering with download URLs valid for urlExpiry
+func NewUploadAttachmentUseCase(
+	taskRepo domain.TaskRepository,
+	commentRepo domain.CommentRepository,
+	attachmentRepo domain.AttachmentRepository,
+	blobStore domain.BlobStore,
+	maxSize int64,
+	urlExpiry time.Duration,
+) *UploadAttachmentUseCase {
+	return &UploadAttachmentUseCase{
+		taskRepo:       taskRepo,
+		commentRepo:    commentRepo,
+		attachmentRepo: attachmentRepo,
+		blobStore:      blobStore,
+		maxSize:        maxSize,
+		urlExpiry:      urlExpiry,
+	}
+}
+
+// Execute attaches a file to a task, or to one of its comments. The content
+// type is detected from the bytes rather than trusted from the client.
+func (uc *UploadAttachmentUseCase) Execute(ctx context.Context, taskID, userID string, req dto.UploadAttachmentRequest) (*dto.AttachmentResponse, error) {
+	task, err := getOwnedTask(uc.taskRepo, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.CommentID != nil {
+		comment, err := uc.commentRepo.GetByID(*req.CommentID)
+		if err != nil || comment.TaskID != task.ID {
+			return nil, apperrors.NewNotFoundError("comment not found")
+		}
+	}
+
+	// Spool the upload so that its size and checksum are known before it is
+	// stored
+	spool, err := os.CreateTemp("", "attachment-*")
+	if err != nil {
+		return nil, apperrors.NewInternalError("failed to buffer upload", err)
+	}
+	defer func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}()
+
+	hash := sha256.New()
+	size, err := io.Copy(io.MultiWriter(spool, hash), io.LimitReader(req.Content, uc.maxSize+1))
+	if err != nil {
+		return nil, uploadReadError(err, uc.maxSize)
+	}
+	if size > uc.maxSize {
+		return nil, fileTooLargeError(uc.maxSize)
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := spool.ReadAt(head, 0)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, apperrors.NewInternalError("failed to read upload", err)
+	}
+	fileName := sanitizeFileName(req.FileName)
+
+	attachment := &domain.Attachment{
+		ID:          uuid.New().String(),
+		TaskID:      task.ID,
+		CommentID:   req.CommentID,
+		UserID:      userID,
+		FileName:    fileName,
+		ContentType: detectContentType(head[:n], fileName),
+		Size:        size,
+		Checksum:    hex.EncodeToString(hash.Sum(nil)),
+		CreatedAt:   time.Now(),
+	}
+	attachment.StorageKey = attachment.ID
+
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		return nil, apperrors.NewInternalError("failed to read upload", err)
+	}
+	if err := uc.blobStore.Put(ctx, attachment.StorageKey, spool, size, attachment.ContentType); err != nil {
+		return nil, apperrors.NewInternalError("failed to store attachment", err)
+	}
+
+	if err := uc.attachmentRepo.Create(attachment); err != nil {
+		// Do not leave a blob behind that nothing refers to
+		_ = uc.blobStore.Delete(ctx, attachment.StorageKey)
+		return nil, apperrors.NewInternalError("failed to create attachment", err)
+	}
+
+	return toAttachmentResponse(ctx, uc.blobStore, attachment, uc.urlExpiry)
+}
+
+func fileTooLargeError(maxSize int64) error {
+	return apperrors.NewPayloadTooLargeError(fmt.Sprintf("file exceeds the limit of %d bytes", maxSize))
+}
+
+// uploadReadError reports a failure to read the upload, which the handler's
+// body limit can cause as well
+func uploadReadError(err error, maxSize int64) error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return fileTooLargeError(maxSize)
+	}
+	return apperrors.NewBadRequestError("failed to read file")
+}
+
+// genericContentTypes are detected types that say little about a file;
+// its extension is a better guide for them
+var genericContentTypes = map[string]bool{
+	"application/octet-stream":  true,
+	"text/plain; charset=utf-8": true,
+	"application/zip":           true,
+}
+
+// activeContentTypes can run script in a browser, so an extension alone is
+// never trusted to claim them
+var activeContentTypes = map[string]bool{
+	"text/html":             true,
+	"application/xhtml+xml": true,
+	"image/svg+xml":         true,
+	"text/javascript":       true,
+	"application/xml":       true,
+	"text/xml":              true,
+}
+
+// detectContentType sniffs the content type from the first bytes of a file.
+// A generic result is refined by the file extension, say a .docx that sniffs
+// as a zip archive, unless the extension claims active content.
+func detectContentType(head []byte, fileName string) string {
+	detected := http.DetectContentType(head)
+	if !genericContentTypes[detected] {
+		return detected
+	}
+
+	byExtension := mime.TypeByExtension(strings.ToLower(filepath.Ext(fileName)))
+	mediaType, _, err := mime.ParseMediaType(byExtension)
+	if byExtension == "" || err != nil || activeContentTypes[mediaType] {
+		return detected
+	}
+	return byExtension
+}
+
+// sanitizeFileName keeps the last path element of a client supplied name,
+// without control characters and within maxFileNameLength
+func sanitizeFileName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, `\`, "/")))
+	if name == "" || name == "." || name == "/" {
+		return "file"
+	}
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = string(runes[:maxFileNameLength])
+	}
+	return name
+}
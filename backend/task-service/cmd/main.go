@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -14,8 +15,13 @@ import (
 	"github.com/todoist/backend/pkg/jwt"
 	"github.com/todoist/backend/pkg/logger"
 	"github.com/todoist/backend/pkg/validator"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+	"github.com/todoist/backend/task-service/infrastructure/client"
 	"github.com/todoist/backend/task-service/infrastructure/config"
+	"github.com/todoist/backend/task-service/infrastructure/messaging"
 	"github.com/todoist/backend/task-service/infrastructure/persistence/postgres"
+	"github.com/todoist/backend/task-service/infrastructure/storage"
 	"github.com/todoist/backend/task-service/interface/http/handler"
 	"github.com/todoist/backend/task-service/interface/http/router"
 )
@@ -50,19 +56,95 @@ func main() {
 	}
 	log.Info("database initialized")
 
+	// Initialize RabbitMQ publisher
+	eventPublisher, err := messaging.NewRabbitMQPublisher(cfg.RabbitMQURL, "events.topic")
+	if err != nil {
+		log.WithError(err).Fatal("failed to initialize event publisher")
+	}
+	defer eventPublisher.Close()
+	log.Info("connected to RabbitMQ")
+
 	// Initialize dependencies
+	transactor := postgres.NewTransactor(db)
 	taskRepo := postgres.NewTaskRepository(db)
+	completionRepo := postgres.NewTaskCompletionRepository(db)
+	labelRepo := postgres.NewLabelRepository(db)
+	commentRepo := postgres.NewCommentRepository(db)
+	activityRepo := postgres.NewActivityRepository(db)
+	reminderRepo := postgres.NewReminderRepository(db)
+	dependencyRepo := postgres.NewDependencyRepository(db)
+	timeEntryRepo := postgres.NewTimeEntryRepository(db)
+	attachmentRepo := postgres.NewAttachmentRepository(db)
+	syncRepo := postgres.NewSyncRepository(db)
+	statsRepo := postgres.NewStatsRepository(db)
+	importRepo := postgres.NewTaskImportRepository(db)
+	calendarFeedRepo := postgres.NewCalendarFeedRepository(db)
+	projectClient := client.NewProjectClient(cfg.ProjectServiceURL)
 	// Parse JWT expiry strings to time.Duration
 	accessTokenExpiry, _ := time.ParseDuration(cfg.JWTExpiry)
 	refreshTokenExpiry, _ := time.ParseDuration(cfg.RefreshTokenExpiry)
 	jwtService := jwt.NewService(cfg.JWTSecret, accessTokenExpiry, refreshTokenExpiry)
 	validatorInstance := validator.New()
 
+	// Initialize attachment storage
+	blobStore, blobServer, err := newBlobStore(cfg)
+	if err != nil {
+		log.WithError(err).Fatal("failed to initialize attachment storage")
+	}
+	maxAttachmentSize, err := strconv.ParseInt(cfg.MaxAttachmentSize, 10, 64)
+	if err != nil || maxAttachmentSize <= 0 {
+		log.Fatal("invalid max attachment size")
+	}
+	attachmentURLExpiry, err := time.ParseDuration(cfg.AttachmentURLExpiry)
+	if err != nil || attachmentURLExpiry <= 0 {
+		log.Fatal("invalid attachment URL expiry")
+	}
+
 	// Initialize handlers
-	taskHandler := handler.NewTaskHandler(validatorInstance, log, taskRepo, jwtService)
+	taskHandler := handler.NewTaskHandler(validatorInstance, log, transactor, taskRepo, completionRepo, projectClient, jwtService, cfg.RequireIfMatch == "true")
+	labelHandler := handler.NewLabelHandler(validatorInstance, log, transactor, labelRepo)
+	commentHandler := handler.NewCommentHandler(validatorInstance, log, transactor, taskRepo, commentRepo)
+	activityHandler := handler.NewActivityHandler(log, taskRepo, activityRepo)
+	reminderHandler := handler.NewReminderHandler(log, taskRepo, reminderRepo)
+	dependencyHandler := handler.NewDependencyHandler(validatorInstance, log, transactor, taskRepo, dependencyRepo)
+	timeEntryHandler := handler.NewTimeEntryHandler(validatorInstance, log, taskRepo, timeEntryRepo)
+	attachmentHandler := handler.NewAttachmentHandler(log, taskRepo, commentRepo, attachmentRepo, blobStore, blobServer, maxAttachmentSize, attachmentURLExpiry)
+	syncHandler := handler.NewSyncHandler(log, transactor, syncRepo)
+	statsHandler := handler.NewStatsHandler(log, transactor, statsRepo)
+	importHandler := handler.NewImportHandler(log, transactor, importRepo)
+	calendarHandler := handler.NewCalendarHandler(log, taskRepo, calendarFeedRepo, cfg.PublicURL)
+
+	// Relay task events from the outbox to RabbitMQ
+	outboxInterval, err := time.ParseDuration(cfg.OutboxInterval)
+	if err != nil {
+		log.WithError(err).Fatal("invalid outbox interval")
+	}
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	defer stopRelay()
+	relay := messaging.NewOutboxRelay(postgres.NewOutboxRepository(db), eventPublisher, log, outboxInterval)
+	go relay.Run(relayCtx)
+
+	// Purge tasks that have been in the trash longer than the retention
+	trashRetention, err := time.ParseDuration(cfg.TrashRetention)
+	if err != nil {
+		log.WithError(err).Fatal("invalid trash retention")
+	}
+	trashPurgeInterval, err := time.ParseDuration(cfg.TrashPurgeInterval)
+	if err != nil {
+		log.WithError(err).Fatal("invalid trash purge interval")
+	}
+	purgeTrashUC := usecase.NewPurgeTrashUseCase(taskRepo, attachmentRepo, blobStore, trashRetention)
+	go runTrashPurge(relayCtx, purgeTrashUC, trashPurgeInterval, log)
+
+	// Fire due reminders; every replica runs a scheduler
+	reminderInterval, err := time.ParseDuration(cfg.ReminderInterval)
+	if err != nil {
+		log.WithError(err).Fatal("invalid reminder interval")
+	}
+	go runReminderScheduler(relayCtx, usecase.NewFireRemindersUseCase(transactor), reminderInterval, log)
 
 	// Initialize router
-	r := router.NewRouter(taskHandler, log)
+	r := router.NewRouter(taskHandler, labelHandler, commentHandler, activityHandler, reminderHandler, dependencyHandler, timeEntryHandler, attachmentHandler, syncHandler, statsHandler, importHandler, calendarHandler, log)
 
 	// Start HTTP server
 	server := &http.Server{
@@ -105,6 +187,87 @@ func main() {
 	log.Info("server stopped")
 }
 
+// newBlobStore creates the attachment store selected by the configuration,
+// along with the handler serving its signed URLs when the store does not
+// serve them itself
+func newBlobStore(cfg *config.Config) (domain.BlobStore, http.Handler, error) {
+	switch cfg.BlobStore {
+	case "local":
+		store, err := storage.NewLocalStore(cfg.BlobLocalDir, cfg.PublicURL, cfg.BlobSigningSecret)
+		if err != nil {
+			return nil, nil, err
+		}
+		return store, store, nil
+	case "s3":
+		store, err := storage.NewS3Store(storage.S3Config{
+			Endpoint:       cfg.S3Endpoint,
+			PublicEndpoint: cfg.S3PublicEndpoint,
+			Region:         cfg.S3Region,
+			Bucket:         cfg.S3Bucket,
+			AccessKey:      cfg.S3AccessKey,
+			SecretKey:      cfg.S3SecretKey,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := store.EnsureBucket(ctx); err != nil {
+			return nil, nil, err
+		}
+		return store, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown blob store %q", cfg.BlobStore)
+	}
+}
+
+// runTrashPurge purges the expired trash on every interval until ctx is done
+func runTrashPurge(ctx context.Context, uc *usecase.PurgeTrashUseCase, interval time.Duration, log *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := uc.Execute(ctx)
+		if err != nil {
+			log.WithError(err).Error("failed to purge trash")
+		} else if purged > 0 {
+			log.WithFields(map[string]interface{}{
+				"purged": purged,
+			}).Info("purged expired trash")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runReminderScheduler fires due reminders on every interval until ctx is
+// done
+func runReminderScheduler(ctx context.Context, uc *usecase.FireRemindersUseCase, interval time.Duration, log *logger.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fired, err := uc.Execute(ctx)
+		if err != nil {
+			log.WithError(err).Error("failed to fire reminders")
+		} else if fired > 0 {
+			log.WithFields(map[string]interface{}{
+				"fired": fired,
+			}).Info("fired reminders")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // initializeDatabase creates the tables needed for the task service
 func initializeDatabase(db *sql.DB) error {
 	// Create tasks table
@@ -117,16 +280,282 @@ func initializeDatabase(db *sql.DB) error {
 		priority INTEGER NOT NULL DEFAULT 1,
 		user_id UUID NOT NULL,
 		project_id UUID,
+		parent_id UUID REFERENCES tasks(id) ON DELETE CASCADE,
+		due_date TIMESTAMP,
+		due_all_day BOOLEAN NOT NULL DEFAULT FALSE,
+		due_timezone TEXT NOT NULL DEFAULT '',
+		recurrence_rule TEXT NOT NULL DEFAULT '',
+		estimated_minutes INTEGER CHECK (estimated_minutes > 0),
+		completed_at TIMESTAMP,
+		position TEXT COLLATE "C" NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		version INTEGER NOT NULL DEFAULT 1,
+		deleted_at TIMESTAMP,
+		sync_xid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+		search_vector tsvector GENERATED ALWAYS AS (
+			setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+			setweight(to_tsvector('english', coalesce(description, '')), 'B')
+		) STORED
+	);
+
+	-- Add columns introduced after the initial schema
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES tasks(id) ON DELETE CASCADE;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence_rule TEXT NOT NULL DEFAULT '';
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS position TEXT COLLATE "C" NOT NULL DEFAULT '';
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_all_day BOOLEAN NOT NULL DEFAULT FALSE;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_timezone TEXT NOT NULL DEFAULT '';
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimated_minutes INTEGER CHECK (estimated_minutes > 0);
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMP;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS sync_xid xid8 NOT NULL DEFAULT pg_current_xact_id();
+
+	-- Tasks completed before completion times were stored were last
+	-- changed when they were completed, at the latest
+	UPDATE tasks SET completed_at = updated_at WHERE status = 'completed' AND completed_at IS NULL;
+
+	-- Rank tasks without a position in creation order within their project
+	-- or inbox; the ranks are zero-padded numbers ending in 1
+	UPDATE tasks SET position = ranked.position
+	FROM (
+		SELECT id, lpad(row_number() OVER (PARTITION BY user_id, project_id ORDER BY created_at, id)::text, 10, '0') || '1' AS position
+		FROM tasks WHERE position = ''
+	) ranked
+	WHERE tasks.id = ranked.id;
+	ALTER TABLE tasks ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+		setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(description, '')), 'B')
+	) STORED;
+
+	-- Stamp every task write with its transaction for incremental sync
+	CREATE OR REPLACE FUNCTION set_task_sync_xid() RETURNS trigger AS $$
+	BEGIN
+		NEW.sync_xid := pg_current_xact_id();
+		RETURN NEW;
+	END;
+	$$ LANGUAGE plpgsql;
+	DROP TRIGGER IF EXISTS tasks_sync_xid ON tasks;
+	CREATE TRIGGER tasks_sync_xid BEFORE UPDATE ON tasks FOR EACH ROW EXECUTE FUNCTION set_task_sync_xid();
+
+	-- Create task completion history table
+	CREATE TABLE IF NOT EXISTS task_completions (
+		id UUID PRIMARY KEY,
+		task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		user_id UUID NOT NULL,
 		due_date TIMESTAMP,
+		completed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		karma INTEGER NOT NULL DEFAULT 0,
+		stats_day DATE
+	);
+	ALTER TABLE task_completions ADD COLUMN IF NOT EXISTS karma INTEGER NOT NULL DEFAULT 0;
+	ALTER TABLE task_completions ADD COLUMN IF NOT EXISTS stats_day DATE;
+
+	-- Create labels and the task/label link table
+	CREATE TABLE IF NOT EXISTS labels (
+		id UUID PRIMARY KEY,
+		user_id UUID NOT NULL,
+		name VARCHAR(60) NOT NULL,
+		color VARCHAR(50) NOT NULL DEFAULT 'charcoal',
 		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
 		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 	);
 
+	CREATE TABLE IF NOT EXISTS task_labels (
+		task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+		PRIMARY KEY (task_id, label_id)
+	);
+
+	-- Create comments table
+	CREATE TABLE IF NOT EXISTS comments (
+		id UUID PRIMARY KEY,
+		task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		user_id UUID NOT NULL,
+		content TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		search_vector tsvector GENERATED ALWAYS AS (to_tsvector('english', content)) STORED
+	);
+	ALTER TABLE comments ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (to_tsvector('english', content)) STORED;
+
+	-- Create transactional outbox for domain events
+	CREATE TABLE IF NOT EXISTS outbox (
+		id UUID PRIMARY KEY,
+		routing_key VARCHAR(255) NOT NULL,
+		payload JSONB NOT NULL,
+		attempts INTEGER NOT NULL DEFAULT 0,
+		last_error TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		dead_at TIMESTAMP
+	);
+	ALTER TABLE outbox ADD COLUMN IF NOT EXISTS dead_at TIMESTAMP;
+
+	-- Create append-only task activity history; entries outlive their tasks
+	CREATE TABLE IF NOT EXISTS task_activity (
+		id UUID PRIMARY KEY,
+		task_id UUID NOT NULL,
+		user_id UUID NOT NULL,
+		action VARCHAR(20) NOT NULL,
+		changes JSONB NOT NULL DEFAULT '{}',
+		created_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+
+	-- Create task reminders; fire_at is the next time a reminder fires
+	CREATE TABLE IF NOT EXISTS task_reminders (
+		id UUID PRIMARY KEY,
+		task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		user_id UUID NOT NULL,
+		remind_at TIMESTAMP,
+		minutes_before INTEGER,
+		fire_at TIMESTAMP,
+		fired_at TIMESTAMP,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		CHECK ((remind_at IS NULL) <> (minutes_before IS NULL))
+	);
+
+	-- Create blocked-by links; task_id waits on blocked_by_id
+	CREATE TABLE IF NOT EXISTS task_dependencies (
+		task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		blocked_by_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		user_id UUID NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (task_id, blocked_by_id),
+		CHECK (task_id <> blocked_by_id)
+	);
+
+	-- Create tracked time; stopped_at is null while the timer runs
+	CREATE TABLE IF NOT EXISTS time_entries (
+		id UUID PRIMARY KEY,
+		task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+		user_id UUID NOT NULL,
+		started_at TIMESTAMP NOT NULL,
+		stopped_at TIMESTAMP,
+		note TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		CHECK (stopped_at IS NULL OR stopped_at >= started_at)
+	);
+
+	-- Create attachment metadata; the bytes live in the blob store. Rows
+	-- outlive their task or comment until their blob has been deleted.
+	CREATE TABLE IF NOT EXISTS attachments (
+		id UUID PRIMARY KEY,
+		task_id UUID NOT NULL,
+		comment_id UUID,
+		user_id UUID NOT NULL,
+		file_name VARCHAR(255) NOT NULL,
+		content_type VARCHAR(255) NOT NULL,
+		size BIGINT NOT NULL,
+		checksum CHAR(64) NOT NULL,
+		storage_key TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+
+	-- Create tombstones of purged tasks for clients syncing from before the
+	-- purge
+	CREATE TABLE IF NOT EXISTS task_tombstones (
+		task_id UUID PRIMARY KEY,
+		user_id UUID NOT NULL,
+		deleted_at TIMESTAMP NOT NULL,
+		sync_xid xid8 NOT NULL DEFAULT pg_current_xact_id()
+	);
+
+	-- Create the record of applied sync commands, which makes retried
+	-- commands no-ops and maps their temp IDs to the tasks they created
+	CREATE TABLE IF NOT EXISTS sync_commands (
+		user_id UUID NOT NULL,
+		uuid UUID NOT NULL,
+		temp_id VARCHAR(64),
+		task_id UUID,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (user_id, uuid)
+	);
+
+	-- Create productivity stats, kept up to date by every completion: goals
+	-- and running totals per user, and completions per day in the user's
+	-- time zone
+	CREATE TABLE IF NOT EXISTS user_stats (
+		user_id UUID PRIMARY KEY,
+		karma INTEGER NOT NULL DEFAULT 0,
+		completed_count INTEGER NOT NULL DEFAULT 0,
+		daily_goal INTEGER NOT NULL DEFAULT 5,
+		weekly_goal INTEGER NOT NULL DEFAULT 25,
+		timezone TEXT NOT NULL DEFAULT '',
+		updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+
+	CREATE TABLE IF NOT EXISTS user_daily_stats (
+		user_id UUID NOT NULL,
+		day DATE NOT NULL,
+		completed INTEGER NOT NULL DEFAULT 0,
+		PRIMARY KEY (user_id, day)
+	);
+
+	-- Create the record of imported rows, which makes importing a file
+	-- again skip the rows it already imported
+	CREATE TABLE IF NOT EXISTS task_imports (
+		user_id UUID NOT NULL,
+		import_key CHAR(64) NOT NULL,
+		task_id UUID,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+		PRIMARY KEY (user_id, import_key)
+	);
+
+	-- Create calendar feed tokens, kept as hashes, one per user
+	CREATE TABLE IF NOT EXISTS calendar_feeds (
+		user_id UUID PRIMARY KEY,
+		token_hash CHAR(64) NOT NULL UNIQUE,
+		created_at TIMESTAMP NOT NULL DEFAULT NOW()
+	);
+
+	-- Seed the stats from the completion history on the first start with
+	-- them, counting days in UTC and the base karma of each completion
+	INSERT INTO user_daily_stats (user_id, day, completed)
+	SELECT user_id, completed_at::date, COUNT(*) FROM task_completions
+	WHERE NOT EXISTS (SELECT 1 FROM user_stats)
+	GROUP BY user_id, completed_at::date
+	ON CONFLICT DO NOTHING;
+	UPDATE task_completions SET karma = 5, stats_day = completed_at::date
+	WHERE NOT EXISTS (SELECT 1 FROM user_stats);
+	INSERT INTO user_stats (user_id, karma, completed_count)
+	SELECT user_id, SUM(completed) * 5, SUM(completed) FROM user_daily_stats
+	WHERE NOT EXISTS (SELECT 1 FROM user_stats)
+	GROUP BY user_id;
+
 	-- Create indexes for better performance
 	CREATE INDEX IF NOT EXISTS idx_tasks_user_id ON tasks(user_id);
 	CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
 	CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks(priority);
 	CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks(project_id);
+	CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks(parent_id);
+	CREATE INDEX IF NOT EXISTS idx_tasks_user_created_at ON tasks(user_id, created_at, id);
+	CREATE INDEX IF NOT EXISTS idx_tasks_user_due_date ON tasks(user_id, due_date, id);
+	CREATE INDEX IF NOT EXISTS idx_tasks_user_priority ON tasks(user_id, priority, id);
+	CREATE INDEX IF NOT EXISTS idx_tasks_user_project_position ON tasks(user_id, project_id, position);
+	CREATE INDEX IF NOT EXISTS idx_tasks_user_completed_at ON tasks(user_id, completed_at, id) WHERE completed_at IS NOT NULL;
+	CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
+	CREATE INDEX IF NOT EXISTS idx_tasks_user_sync_xid ON tasks(user_id, sync_xid);
+	CREATE INDEX IF NOT EXISTS idx_task_completions_task_id ON task_completions(task_id);
+	CREATE INDEX IF NOT EXISTS idx_task_completions_user_id ON task_completions(user_id, completed_at);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_user_name ON labels(user_id, lower(name));
+	CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels(label_id);
+	CREATE INDEX IF NOT EXISTS idx_comments_task_id ON comments(task_id, created_at);
+	CREATE INDEX IF NOT EXISTS idx_tasks_search ON tasks USING GIN(search_vector);
+	CREATE INDEX IF NOT EXISTS idx_comments_search ON comments USING GIN(search_vector);
+	CREATE INDEX IF NOT EXISTS idx_outbox_next_attempt_at ON outbox(next_attempt_at);
+	CREATE INDEX IF NOT EXISTS idx_task_activity_user ON task_activity(user_id, created_at DESC, id DESC);
+	CREATE INDEX IF NOT EXISTS idx_task_activity_task ON task_activity(task_id, created_at DESC, id DESC);
+	CREATE INDEX IF NOT EXISTS idx_task_reminders_task_id ON task_reminders(task_id, created_at);
+	CREATE INDEX IF NOT EXISTS idx_task_reminders_fire_at ON task_reminders(fire_at) WHERE fire_at IS NOT NULL;
+	CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocked_by ON task_dependencies(blocked_by_id);
+	CREATE INDEX IF NOT EXISTS idx_task_dependencies_user_id ON task_dependencies(user_id);
+	CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries(task_id, started_at);
+	CREATE INDEX IF NOT EXISTS idx_time_entries_user_started_at ON time_entries(user_id, started_at);
+	CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries(user_id) WHERE stopped_at IS NULL;
+	CREATE INDEX IF NOT EXISTS idx_attachments_task_id ON attachments(task_id, created_at);
+	CREATE INDEX IF NOT EXISTS idx_task_tombstones_user_sync_xid ON task_tombstones(user_id, sync_xid);
+	CREATE INDEX IF NOT EXISTS idx_sync_commands_temp_id ON sync_commands(user_id, temp_id, created_at) WHERE temp_id IS NOT NULL;
 	`
 
 	// Execute the SQL
@@ -0,0 +1,47 @@
+package domain
+
+import "time"
+
+// Activity actions
+const (
+	ActivityCreated   = "created"
+	ActivityUpdated   = "updated"
+	ActivityCompleted = "completed"
+	ActivityDeleted   = "deleted"
+	ActivityRestored  = "restored"
+)
+
+// Activity is an immutable audit entry for one change to a task. Changes
+// holds the before and after value of every field that changed, keyed like
+// DiffTasks.
+type Activity struct {
+	ID        string
+	TaskID    string
+	UserID    string
+	Action    string
+	Changes   map[string]FieldChange
+	CreatedAt time.Time
+}
+
+// ActivityCursor marks the last entry of a page of activity, which is
+// ordered newest first
+type ActivityCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// ActivityQuery selects a page of a user's activity, optionally limited to
+// one task
+type ActivityQuery struct {
+	TaskID *string
+	After  *ActivityCursor
+	Limit  int
+}
+
+// ActivityRepository persists the activity history. Entries are only ever
+// added; they outlive the tasks they describe.
+type ActivityRepository interface {
+	Add(activity *Activity) error
+	// Find lists the user's activity matching query, newest first
+	Find(userID string, query ActivityQuery) ([]*Activity, error)
+}
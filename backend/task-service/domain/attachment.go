@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+)
+
+// Attachment is a file attached to a task, or to one of its comments when
+// CommentID is set. The bytes live in a BlobStore under StorageKey.
+type Attachment struct {
+	ID          string
+	TaskID      string
+	CommentID   *string
+	UserID      string
+	FileName    string
+	ContentType string // sniffed from the content, not taken from the client
+	Size        int64
+	Checksum    string // hex SHA-256 of the content
+	StorageKey  string
+	CreatedAt   time.Time
+}
+
+// AttachmentRepository persists attachment metadata. Attachments are not
+// removed with their task or comment; GetOrphaned finds them so that their
+// blobs can be deleted first.
+type AttachmentRepository interface {
+	Create(attachment *Attachment) error
+	GetByID(id string) (*Attachment, error)
+	// GetByTaskID lists a task's attachments, oldest first, leaving out
+	// those of deleted comments
+	GetByTaskID(taskID string) ([]*Attachment, error)
+	Delete(id string) error
+	// GetOrphaned lists up to limit attachments whose task or comment no
+	// longer exists
+	GetOrphaned(limit int) ([]*Attachment, error)
+}
+
+// ErrBlobNotFound is returned by a BlobStore for a key it does not hold
+var ErrBlobNotFound = errors.New("blob not found")
+
+// BlobStore keeps the bytes of attachments
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes a blob; deleting a missing blob is not an error
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a URL that downloads the blob as fileName, without
+	// any other credentials, until expiry has passed
+	SignedURL(ctx context.Context, key, fileName, contentType string, expiry time.Duration) (string, error)
+}
@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// CalendarFeed lets calendar apps, which cannot send a JWT, read a user's
+// task feed with a secret token. Only a hash of the token is stored, and a
+// user has at most one token; a new one replaces the old.
+type CalendarFeed struct {
+	UserID    string
+	TokenHash string
+	CreatedAt time.Time
+}
+
+// HashFeedToken returns the stored form of a feed token
+func HashFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+type CalendarFeedRepository interface {
+	// Save stores the user's feed, replacing the previous token
+	Save(feed *CalendarFeed) error
+	// GetByTokenHash returns the feed of a token, and sql.ErrNoRows for a
+	// token that is unknown or was revoked
+	GetByTokenHash(tokenHash string) (*CalendarFeed, error)
+	// Delete revokes the user's token and reports whether there was one
+	Delete(userID string) (bool, error)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Comment is a note left on a task
+type Comment struct {
+	ID        string
+	TaskID    string
+	UserID    string
+	Content   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CommentRepository interface {
+	Create(comment *Comment) error
+	GetByID(id string) (*Comment, error)
+	GetByTaskID(taskID string) ([]*Comment, error)
+	Update(comment *Comment) error
+	Delete(id string) error
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// TaskDependency records that a task cannot be completed before another
+// task, its blocker, is done
+type TaskDependency struct {
+	TaskID      string
+	BlockedByID string
+	UserID      string
+	CreatedAt   time.Time
+}
+
+// ErrDependencyCycle is returned for a dependency that would make a task
+// wait on itself
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+// CheckDependencyCycle reports ErrDependencyCycle when adding dependency to
+// the existing ones would close a cycle, that is when its task already
+// blocks its blocker, directly or through other tasks
+func CheckDependencyCycle(existing []*TaskDependency, dependency *TaskDependency) error {
+	blockedBy := make(map[string][]string)
+	for _, d := range existing {
+		blockedBy[d.TaskID] = append(blockedBy[d.TaskID], d.BlockedByID)
+	}
+
+	// Walk from the new blocker to everything it waits on
+	seen := make(map[string]bool)
+	pending := []string{dependency.BlockedByID}
+	for len(pending) > 0 {
+		id := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		if id == dependency.TaskID {
+			return ErrDependencyCycle
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		pending = append(pending, blockedBy[id]...)
+	}
+	return nil
+}
+
+// DependencyRepository persists blocked-by links between tasks. A blocker
+// is open while it is pending and not in the trash; trashed tasks neither
+// block nor are blocked.
+type DependencyRepository interface {
+	Add(dependency *TaskDependency) error
+	Get(taskID, blockedByID string) (*TaskDependency, error)
+	Remove(taskID, blockedByID string) error
+	// GetByUserID lists all of a user's dependencies, for cycle checks
+	GetByUserID(userID string) ([]*TaskDependency, error)
+	// GetBlockerIDs lists the tasks a task waits on, GetBlockedIDs the tasks
+	// waiting on it
+	GetBlockerIDs(taskID string) ([]string, error)
+	GetBlockedIDs(taskID string) ([]string, error)
+	// OpenBlockers lists the dependencies of the tasks whose blocker is
+	// still open
+	OpenBlockers(taskIDs []string) ([]*TaskDependency, error)
+	// LockUser serializes changes to a user's dependencies until the
+	// transaction ends, so concurrent additions cannot form a cycle together
+	LockUser(userID string) error
+}
@@ -0,0 +1,122 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Date layouts of due values
+const (
+	DueDateLayout      = "2006-01-02"
+	dueLocalTimeLayout = "2006-01-02T15:04:05"
+)
+
+// ErrInvalidDue is returned for due values that cannot be read
+var ErrInvalidDue = errors.New("invalid due date")
+
+// Due is when a task is due: a whole day for an all-day task, or a moment
+// in time, optionally tied to the time zone it was set in
+type Due struct {
+	// At is the moment a timed task is due, in UTC. For an all-day task it
+	// is the date at midnight UTC.
+	At       time.Time
+	AllDay   bool
+	Timezone string // IANA zone of a timed due, empty for UTC
+}
+
+// ParseDue reads a due value from its API form. A date ("2024-05-17") makes
+// an all-day task. A datetime is RFC3339, or a local time
+// ("2024-05-17T09:00:00") read in timezone; it takes precedence over the
+// date, which then only echoes its day. For compatibility a date may also be
+// an RFC3339 datetime. All empty means no due date.
+func ParseDue(date, datetime, timezone string) (*Due, error) {
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		loc, err = time.LoadLocation(timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: unknown time zone %q", ErrInvalidDue, timezone)
+		}
+	}
+
+	if datetime == "" && date != "" {
+		if day, err := time.Parse(DueDateLayout, date); err == nil {
+			if timezone != "" {
+				return nil, fmt.Errorf("%w: a time zone needs a due datetime", ErrInvalidDue)
+			}
+			return &Due{At: day, AllDay: true}, nil
+		}
+		// Clients used to send an RFC3339 due_date
+		datetime = date
+	}
+	if datetime == "" {
+		if timezone != "" {
+			return nil, fmt.Errorf("%w: a time zone needs a due datetime", ErrInvalidDue)
+		}
+		return nil, nil
+	}
+
+	at, err := time.Parse(time.RFC3339, datetime)
+	if err != nil && timezone != "" {
+		at, err = time.ParseInLocation(dueLocalTimeLayout, datetime, loc)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: expected a YYYY-MM-DD date, an RFC3339 datetime or a local datetime with a time zone", ErrInvalidDue)
+	}
+	return &Due{At: at.UTC(), Timezone: timezone}, nil
+}
+
+// Due returns the task's due value, nil when it has none
+func (t *Task) Due() *Due {
+	if t.DueDate == nil {
+		return nil
+	}
+	return &Due{At: *t.DueDate, AllDay: t.DueAllDay, Timezone: t.DueTimezone}
+}
+
+// SetDue replaces the task's due value; nil removes it
+func (t *Task) SetDue(due *Due) {
+	if due == nil {
+		t.DueDate, t.DueAllDay, t.DueTimezone = nil, false, ""
+		return
+	}
+	at := due.At.UTC()
+	t.DueDate, t.DueAllDay, t.DueTimezone = &at, due.AllDay, due.Timezone
+}
+
+// DueLocation is the time zone the due date is shown and repeated in, UTC
+// for all-day tasks and datetimes without a zone
+func (t *Task) DueLocation() *time.Location {
+	if t.DueAllDay || t.DueTimezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(t.DueTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LocalDue returns the due date in DueLocation, nil when there is none
+func (t *Task) LocalDue() *time.Time {
+	if t.DueDate == nil {
+		return nil
+	}
+	local := t.DueDate.In(t.DueLocation())
+	return &local
+}
+
+// IsOverdue reports whether the task is past due at now, whose location is
+// the user's. An all-day task is overdue once its day has passed in the
+// user's time zone, a timed one once its moment has passed.
+func (t *Task) IsOverdue(now time.Time) bool {
+	if t.DueDate == nil {
+		return false
+	}
+	if t.DueAllDay {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		return t.DueDate.Before(today)
+	}
+	return t.DueDate.Before(now)
+}
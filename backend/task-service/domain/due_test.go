@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDue(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     string
+		datetime string
+		timezone string
+		want     *Due
+	}{
+		{
+			name: "none",
+		},
+		{
+			name: "all day",
+			date: "2024-05-17",
+			want: &Due{At: time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC), AllDay: true},
+		},
+		{
+			name:     "rfc3339",
+			datetime: "2024-05-17T09:00:00+02:00",
+			want:     &Due{At: time.Date(2024, 5, 17, 7, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "rfc3339 date",
+			date: "2024-05-17T09:00:00Z",
+			want: &Due{At: time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "local time in zone",
+			datetime: "2024-05-17T09:00:00",
+			timezone: "Europe/Berlin",
+			want:     &Due{At: time.Date(2024, 5, 17, 7, 0, 0, 0, time.UTC), Timezone: "Europe/Berlin"},
+		},
+		{
+			name:     "datetime wins over date",
+			date:     "2024-05-17",
+			datetime: "2024-05-18T09:00:00Z",
+			want:     &Due{At: time.Date(2024, 5, 18, 9, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDue(tt.date, tt.datetime, tt.timezone)
+			if err != nil {
+				t.Fatalf("ParseDue error: %v", err)
+			}
+			if tt.want == nil {
+				if got != nil {
+					t.Errorf("ParseDue = %+v, want nil", got)
+				}
+				return
+			}
+			if got == nil || !got.At.Equal(tt.want.At) || got.At.Location() != time.UTC ||
+				got.AllDay != tt.want.AllDay || got.Timezone != tt.want.Timezone {
+				t.Errorf("ParseDue = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseDueInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		date     string
+		datetime string
+		timezone string
+	}{
+		{name: "unknown zone", datetime: "2024-05-17T09:00:00", timezone: "Mars/Olympus"},
+		{name: "zone with date", date: "2024-05-17", timezone: "Europe/Berlin"},
+		{name: "zone alone", timezone: "Europe/Berlin"},
+		{name: "local time without zone", datetime: "2024-05-17T09:00:00"},
+		{name: "malformed date", date: "17/05/2024"},
+		{name: "malformed datetime", datetime: "tomorrow"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseDue(tt.date, tt.datetime, tt.timezone)
+			if !errors.Is(err, ErrInvalidDue) {
+				t.Errorf("ParseDue error = %v, want ErrInvalidDue", err)
+			}
+		})
+	}
+}
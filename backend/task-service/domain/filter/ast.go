@@ -0,0 +1,122 @@
+// Package filter parses Todoist-style task filter expressions such as
+// "(p1 | p2) & overdue & #Work & !@waiting" into an abstract syntax tree
+package filter
+
+import "time"
+
+// Expr is a node of a parsed filter expression
+type Expr interface {
+	// Pos is the character offset of the node in the source expression
+	Pos() int
+}
+
+// And matches tasks matched by both sides
+type And struct {
+	Left, Right Expr
+	At          int
+}
+
+// Or matches tasks matched by either side
+type Or struct {
+	Left, Right Expr
+	At          int
+}
+
+// Not matches tasks not matched by Expr
+type Not struct {
+	Expr Expr
+	At   int
+}
+
+// All matches every task ("all")
+type All struct {
+	At int
+}
+
+// Priority matches a Todoist priority level, p1 (highest) to p4
+type Priority struct {
+	Level int
+	At    int
+}
+
+// Project matches tasks in a project referenced by name or ID ("#Work").
+// IDs holds the project IDs the name resolves to and is filled in before
+// the expression is evaluated.
+type Project struct {
+	Name string
+	IDs  []string
+	At   int
+}
+
+// Label matches tasks carrying a label ("@waiting")
+type Label struct {
+	Name string
+	At   int
+}
+
+// NoLabels matches tasks without any label ("no labels")
+type NoLabels struct {
+	At int
+}
+
+type DueKind int
+
+const (
+	DueToday DueKind = iota
+	DueTomorrow
+	DueYesterday
+	DueOverdue
+	DueNone
+	DueWithinDays
+	DueBefore
+	DueAfter
+)
+
+// Due matches tasks by due date. Days is set for DueWithinDays ("7 days")
+// and Date for DueBefore and DueAfter ("due before: 2024-05-01").
+type Due struct {
+	Kind DueKind
+	Days int
+	Date time.Time
+	At   int
+}
+
+// Recurring matches recurring tasks ("recurring")
+type Recurring struct {
+	At int
+}
+
+// Subtask matches tasks that have a parent ("subtask")
+type Subtask struct {
+	At int
+}
+
+func (e *And) Pos() int       { return e.At }
+func (e *Or) Pos() int        { return e.At }
+func (e *Not) Pos() int       { return e.At }
+func (e *All) Pos() int       { return e.At }
+func (e *Priority) Pos() int  { return e.At }
+func (e *Project) Pos() int   { return e.At }
+func (e *Label) Pos() int     { return e.At }
+func (e *NoLabels) Pos() int  { return e.At }
+func (e *Due) Pos() int       { return e.At }
+func (e *Recurring) Pos() int { return e.At }
+func (e *Subtask) Pos() int   { return e.At }
+
+// Walk calls fn for every node of the expression, parents before children
+func Walk(expr Expr, fn func(Expr)) {
+	if expr == nil {
+		return
+	}
+	fn(expr)
+	switch e := expr.(type) {
+	case *And:
+		Walk(e.Left, fn)
+		Walk(e.Right, fn)
+	case *Or:
+		Walk(e.Left, fn)
+		Walk(e.Right, fn)
+	case *Not:
+		Walk(e.Expr, fn)
+	}
+}
@@ -0,0 +1,256 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseError describes an invalid filter expression. Position is the
+// zero-based character offset where the problem was found.
+type ParseError struct {
+	Message  string `json:"message"`
+	Position int    `json:"position"`
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("filter: %s at position %d", e.Message, e.Position)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenTerm
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+var (
+	priorityPattern   = regexp.MustCompile(`^p([1-4])$`)
+	withinDaysPattern = regexp.MustCompile(`^(?:next )?(\d+) days?$`)
+	dueDatePattern    = regexp.MustCompile(`^due (before|after):\s*(\S+)$`)
+	spacePattern      = regexp.MustCompile(`\s+`)
+)
+
+// Parse parses a filter expression. Operators are & (and), | (or) and
+// ! (not), with & binding tighter than |, and parentheses for grouping.
+func Parse(input string) (Expr, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	expr, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if tok := p.peek(); tok.kind != tokenEOF {
+		if tok.kind == tokenRParen {
+			return nil, &ParseError{Message: "unmatched ')'", Position: tok.pos}
+		}
+		return nil, &ParseError{Message: fmt.Sprintf("unexpected %q", tok.text), Position: tok.pos}
+	}
+	return expr, nil
+}
+
+func lex(input string) ([]token, error) {
+	runes := []rune(input)
+	var tokens []token
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '&':
+			tokens = append(tokens, token{kind: tokenAnd, text: "&", pos: i})
+			i++
+		case r == '|':
+			tokens = append(tokens, token{kind: tokenOr, text: "|", pos: i})
+			i++
+		case r == '!':
+			tokens = append(tokens, token{kind: tokenNot, text: "!", pos: i})
+			i++
+		case r == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case r == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case r == ',':
+			return nil, &ParseError{Message: "multiple comma-separated queries are not supported", Position: i}
+		default:
+			start := i
+			for i < len(runes) && !strings.ContainsRune("&|!(),", runes[i]) {
+				i++
+			}
+			text := strings.TrimSpace(string(runes[start:i]))
+			tokens = append(tokens, token{kind: tokenTerm, text: text, pos: start})
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) parseOr() (Expr, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		op := p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Or{Left: left, Right: right, At: op.pos}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expr, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		op := p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &And{Left: left, Right: right, At: op.pos}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (Expr, error) {
+	if p.peek().kind == tokenNot {
+		op := p.next()
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &Not{Expr: expr, At: op.pos}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Expr, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenLParen:
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, &ParseError{Message: "unclosed '('", Position: tok.pos}
+		}
+		return expr, nil
+	case tokenTerm:
+		return parseTerm(tok)
+	case tokenEOF:
+		return nil, &ParseError{Message: "unexpected end of filter", Position: tok.pos}
+	default:
+		return nil, &ParseError{Message: fmt.Sprintf("unexpected %q", tok.text), Position: tok.pos}
+	}
+}
+
+func parseTerm(tok token) (Expr, error) {
+	text := tok.text
+	if strings.HasPrefix(text, "#") {
+		name := strings.TrimSpace(text[1:])
+		if name == "" {
+			return nil, &ParseError{Message: "project name expected after '#'", Position: tok.pos}
+		}
+		return &Project{Name: name, At: tok.pos}, nil
+	}
+	if strings.HasPrefix(text, "@") {
+		name := strings.TrimSpace(text[1:])
+		if name == "" {
+			return nil, &ParseError{Message: "label name expected after '@'", Position: tok.pos}
+		}
+		return &Label{Name: name, At: tok.pos}, nil
+	}
+
+	lowered := spacePattern.ReplaceAllString(strings.ToLower(text), " ")
+	switch lowered {
+	case "all":
+		return &All{At: tok.pos}, nil
+	case "today":
+		return &Due{Kind: DueToday, At: tok.pos}, nil
+	case "tomorrow":
+		return &Due{Kind: DueTomorrow, At: tok.pos}, nil
+	case "yesterday":
+		return &Due{Kind: DueYesterday, At: tok.pos}, nil
+	case "overdue", "od":
+		return &Due{Kind: DueOverdue, At: tok.pos}, nil
+	case "no date", "no due date":
+		return &Due{Kind: DueNone, At: tok.pos}, nil
+	case "recurring":
+		return &Recurring{At: tok.pos}, nil
+	case "subtask", "subtasks":
+		return &Subtask{At: tok.pos}, nil
+	case "no labels":
+		return &NoLabels{At: tok.pos}, nil
+	}
+
+	if m := priorityPattern.FindStringSubmatch(lowered); m != nil {
+		level, _ := strconv.Atoi(m[1])
+		return &Priority{Level: level, At: tok.pos}, nil
+	}
+
+	if m := withinDaysPattern.FindStringSubmatch(lowered); m != nil {
+		days, err := strconv.Atoi(m[1])
+		if err != nil || days < 1 {
+			return nil, &ParseError{Message: "number of days must be positive", Position: tok.pos}
+		}
+		return &Due{Kind: DueWithinDays, Days: days, At: tok.pos}, nil
+	}
+
+	if m := dueDatePattern.FindStringSubmatch(lowered); m != nil {
+		date, err := time.Parse("2006-01-02", m[2])
+		if err != nil {
+			return nil, &ParseError{Message: fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", m[2]), Position: tok.pos}
+		}
+		kind := DueBefore
+		if m[1] == "after" {
+			kind = DueAfter
+		}
+		return &Due{Kind: kind, Date: date, At: tok.pos}, nil
+	}
+
+	return nil, &ParseError{Message: fmt.Sprintf("unknown filter term %q", text), Position: tok.pos}
+}
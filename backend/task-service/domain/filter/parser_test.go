@@ -0,0 +1,113 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Expr
+	}{
+		{"today", &Due{Kind: DueToday, At: 0}},
+		{"  All  ", &All{At: 2}},
+		{"No  Date", &Due{Kind: DueNone, At: 0}},
+		{"next 7 days", &Due{Kind: DueWithinDays, Days: 7, At: 0}},
+		{"due before: 2024-05-17", &Due{Kind: DueBefore, Date: time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC), At: 0}},
+		{"!!p4", &Not{Expr: &Not{Expr: &Priority{Level: 4, At: 2}, At: 1}, At: 0}},
+		{
+			"#My Project & no labels",
+			&And{Left: &Project{Name: "My Project", At: 0}, Right: &NoLabels{At: 14}, At: 12},
+		},
+		{
+			// & binds tighter than |
+			"today | tomorrow & p1",
+			&Or{
+				Left:  &Due{Kind: DueToday, At: 0},
+				Right: &And{Left: &Due{Kind: DueTomorrow, At: 8}, Right: &Priority{Level: 1, At: 19}, At: 17},
+				At:    6,
+			},
+		},
+		{
+			"(p1 | p2) & overdue & #Work & !@waiting",
+			&And{
+				Left: &And{
+					Left: &And{
+						Left:  &Or{Left: &Priority{Level: 1, At: 1}, Right: &Priority{Level: 2, At: 6}, At: 4},
+						Right: &Due{Kind: DueOverdue, At: 12},
+						At:    10,
+					},
+					Right: &Project{Name: "Work", At: 22},
+					At:    20,
+				},
+				Right: &Not{Expr: &Label{Name: "waiting", At: 31}, At: 30},
+				At:    28,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := Parse(tt.input)
+			if err != nil {
+				t.Fatalf("Parse(%q) error: %v", tt.input, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %s, want %s", tt.input, format(got), format(tt.want))
+			}
+		})
+	}
+}
+
+func TestParseErrorPosition(t *testing.T) {
+	tests := []struct {
+		input    string
+		message  string
+		position int
+	}{
+		{"today)", "unmatched ')'", 5},
+		{"(today | p1", "unclosed '('", 0},
+		{"p1 & (today", "unclosed '('", 5},
+		{"today &", "unexpected end of filter", 7},
+		{"", "unexpected end of filter", 0},
+		{"today, overdue", "multiple comma-separated queries are not supported", 5},
+		{"today & someday", "unknown filter term \"someday\"", 8},
+		{"p1 &  #", "project name expected after '#'", 6},
+		{"@", "label name expected after '@'", 0},
+		{"due before: 2024-13-01", "invalid date \"2024-13-01\", expected YYYY-MM-DD", 0},
+		{"next 0 days", "number of days must be positive", 0},
+		{"#été & soon", "unknown filter term \"soon\"", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			_, err := Parse(tt.input)
+			var parseErr *ParseError
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("Parse(%q) error = %v, want a *ParseError", tt.input, err)
+			}
+			if parseErr.Message != tt.message || parseErr.Position != tt.position {
+				t.Errorf("Parse(%q) error = %q at %d, want %q at %d",
+					tt.input, parseErr.Message, parseErr.Position, tt.message, tt.position)
+			}
+		})
+	}
+}
+
+// format prints an expression with its positions, for failure messages
+func format(expr Expr) string {
+	switch e := expr.(type) {
+	case *And:
+		return fmt.Sprintf("(%s &@%d %s)", format(e.Left), e.At, format(e.Right))
+	case *Or:
+		return fmt.Sprintf("(%s |@%d %s)", format(e.Left), e.At, format(e.Right))
+	case *Not:
+		return fmt.Sprintf("!@%d%s", e.At, format(e.Expr))
+	default:
+		return fmt.Sprintf("%T%+v", expr, expr)
+	}
+}
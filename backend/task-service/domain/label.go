@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const DefaultLabelColor = "charcoal"
+
+var ErrInvalidLabelName = errors.New("label name must be non-empty and contain no whitespace or '@'")
+
+// Label is a per-user tag that can be attached to any number of tasks
+// across projects
+type Label struct {
+	ID        string
+	UserID    string
+	Name      string
+	Color     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NormalizeLabelName trims a label name and strips a leading '@'. Names are
+// single words so that they can be referenced as @name.
+func NormalizeLabelName(name string) (string, error) {
+	name = strings.TrimPrefix(strings.TrimSpace(name), "@")
+	if name == "" || len(name) > 60 || strings.ContainsRune(name, '@') || strings.IndexFunc(name, unicode.IsSpace) >= 0 {
+		return "", ErrInvalidLabelName
+	}
+	return name, nil
+}
+
+type LabelRepository interface {
+	Create(label *Label) error
+	GetByID(id string) (*Label, error)
+	GetByUserID(userID string) ([]*Label, error)
+	// GetByNames returns the user's labels with the given names, matched
+	// case-insensitively
+	GetByNames(userID string, names []string) ([]*Label, error)
+	Update(label *Label) error
+	Delete(id string) error
+
+	// GetTaskIDs returns the IDs of the tasks outside the trash that carry
+	// the label
+	GetTaskIDs(labelID string) ([]string, error)
+	// TouchTasks bumps the version of the tasks GetTaskIDs returns, whose
+	// label names change with a rename or deletion of the label
+	TouchTasks(labelID string, at time.Time) error
+
+	// SetTaskLabels replaces the labels attached to a task
+	SetTaskLabels(taskID string, labelIDs []string) error
+}
@@ -0,0 +1,35 @@
+package domain
+
+import "time"
+
+// OutboxMessage is a serialized domain event stored in the same transaction
+// as the change it describes, waiting to be relayed to the message broker
+type OutboxMessage struct {
+	ID         string
+	RoutingKey string
+	Payload    []byte
+	Attempts   int
+	LastError  string
+	CreatedAt  time.Time
+}
+
+// OutboxRepository persists the transactional outbox. Delivery is
+// at-least-once: a relay that crashes after publishing but before marking a
+// message publishes it again once its lease expires. Messages are published
+// with their ID as the AMQP MessageId for consumers to de-duplicate on.
+type OutboxRepository interface {
+	Add(message *OutboxMessage) error
+	// ClaimDue leases up to limit messages that are due for delivery, oldest
+	// first. Leased messages are hidden from other relays until lease passes.
+	ClaimDue(limit int, lease time.Duration) ([]*OutboxMessage, error)
+	// ExtendLease keeps claimed messages hidden for another lease, so a relay
+	// still working through a batch does not lose it to another relay
+	ExtendLease(ids []string, lease time.Duration) error
+	// MarkPublished removes a delivered message
+	MarkPublished(id string) error
+	// MarkFailed records a failed delivery and schedules the next attempt
+	MarkFailed(id string, reason string, retryAfter time.Duration) error
+	// MarkDead records the last failed delivery of a message that is given
+	// up on. Dead messages are kept for inspection but never claimed again.
+	MarkDead(id string, reason string) error
+}
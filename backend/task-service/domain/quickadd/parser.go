@@ -0,0 +1,517 @@
+package quickadd
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// SpanKind names what a part of the input was read as
+type SpanKind string
+
+const (
+	SpanDue        SpanKind = "due"
+	SpanRecurrence SpanKind = "recurrence"
+	SpanPriority   SpanKind = "priority"
+	SpanProject    SpanKind = "project"
+	SpanLabel      SpanKind = "label"
+)
+
+// Span is a part of the input that was extracted. Start and End are
+// zero-based character offsets; End is exclusive.
+type Span struct {
+	Kind  SpanKind
+	Start int
+	End   int
+	Text  string
+}
+
+// Result is what Parse extracted from a line of quick add text
+type Result struct {
+	Due *time.Time // in the location of the reference time
+	// DueHasTime reports whether a time of day was given with the due date
+	DueHasTime bool
+	// Recurrence is the recurrence phrase as typed, e.g. "every monday"
+	Recurrence string
+	// PriorityLevel is 1 (highest) to 4, zero when none was given
+	PriorityLevel int
+	Project       string
+	Labels        []string
+	Spans         []Span
+
+	words []word
+}
+
+// Title is the input without the extracted parts
+func (r *Result) Title() string {
+	var parts []string
+	for _, w := range r.words {
+		if !r.extracted(w) {
+			parts = append(parts, w.text)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// Unextract puts the spans of a kind back into the title, for instance a
+// project that does not exist
+func (r *Result) Unextract(kind SpanKind) {
+	spans := r.Spans[:0]
+	for _, span := range r.Spans {
+		if span.Kind != kind {
+			spans = append(spans, span)
+		}
+	}
+	r.Spans = spans
+
+	switch kind {
+	case SpanProject:
+		r.Project = ""
+	case SpanLabel:
+		r.Labels = nil
+	case SpanPriority:
+		r.PriorityLevel = 0
+	}
+}
+
+func (r *Result) extracted(w word) bool {
+	for _, span := range r.Spans {
+		if w.start >= span.Start && w.end <= span.End {
+			return true
+		}
+	}
+	return false
+}
+
+// word is a whitespace separated part of the input. Key is the lower-case
+// text without trailing punctuation, used for matching.
+type word struct {
+	text  string
+	key   string
+	start int
+	end   int
+}
+
+var (
+	priorityPattern = regexp.MustCompile(`^p([1-4])$`)
+	isoDatePattern  = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	yearPattern     = regexp.MustCompile(`^\d{4}$`)
+)
+
+// maxRecurrenceWords bounds the length of a recurrence phrase
+const maxRecurrenceWords = 8
+
+// recurrenceFillers may not end a recurrence phrase, so that "every day
+// and" leaves "and" in the title
+var recurrenceFillers = map[string]bool{"on": true, "the": true, "and": true, "of": true, "at": true}
+
+var weekdays = map[string]time.Weekday{
+	"monday": time.Monday, "tuesday": time.Tuesday, "wednesday": time.Wednesday,
+	"thursday": time.Thursday, "friday": time.Friday, "saturday": time.Saturday, "sunday": time.Sunday,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January, "feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March, "apr": time.April, "april": time.April,
+	"may": time.May, "jun": time.June, "june": time.June, "jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August, "sep": time.September, "sept": time.September,
+	"september": time.September, "oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November, "dec": time.December, "december": time.December,
+}
+
+var counts = map[string]int{"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5}
+
+// Parse reads a quick add line such as "Pay rent every month on the 1st p1
+// #Home @bills". Relative dates are resolved against now, in its location.
+// Parts that are not understood stay in the title, so Parse never fails.
+func Parse(text string, now time.Time) *Result {
+	p := &parser{
+		result: &Result{words: splitWords(text)},
+		runes:  []rune(text),
+		now:    now,
+	}
+	p.parseMarkers()
+	p.parseRecurrence()
+	p.parseDue()
+
+	sort.Slice(p.result.Spans, func(i, j int) bool {
+		return p.result.Spans[i].Start < p.result.Spans[j].Start
+	})
+	return p.result
+}
+
+type parser struct {
+	result *Result
+	runes  []rune
+	now    time.Time
+
+	date    *time.Time
+	hour    int
+	minute  int
+	hasTime bool
+}
+
+func splitWords(text string) []word {
+	var words []word
+	start := -1
+	runes := []rune(text)
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) && !unicode.IsSpace(runes[i]) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			w := string(runes[start:i])
+			words = append(words, word{
+				text:  w,
+				key:   strings.ToLower(strings.TrimRight(w, ",.;:!?")),
+				start: start,
+				end:   i,
+			})
+			start = -1
+		}
+	}
+	return words
+}
+
+// extract records words[from:to] as a span
+func (p *parser) extract(kind SpanKind, from, to int) {
+	words := p.result.words
+	start, end := words[from].start, words[to-1].end
+	p.result.Spans = append(p.result.Spans, Span{
+		Kind:  kind,
+		Start: start,
+		End:   end,
+		Text:  string(p.runes[start:end]),
+	})
+}
+
+func (p *parser) taken(i int) bool {
+	return p.result.extracted(p.result.words[i])
+}
+
+// parseMarkers extracts priorities (p1), projects (#Home) and labels
+// (@bills). The first priority and project win; later ones stay in the
+// title.
+func (p *parser) parseMarkers() {
+	for i, w := range p.result.words {
+		name := strings.TrimRight(w.text, ",.;:!?")
+		switch {
+		case priorityPattern.MatchString(w.key):
+			if p.result.PriorityLevel == 0 {
+				p.result.PriorityLevel, _ = strconv.Atoi(w.key[1:])
+				p.extract(SpanPriority, i, i+1)
+			}
+		case strings.HasPrefix(name, "#") && len(name) > 1:
+			if p.result.Project == "" {
+				p.result.Project = name[1:]
+				p.extract(SpanProject, i, i+1)
+			}
+		case strings.HasPrefix(name, "@") && len(name) > 1:
+			p.result.Labels = append(p.result.Labels, name[1:])
+			p.extract(SpanLabel, i, i+1)
+		}
+	}
+}
+
+// parseRecurrence extracts the longest phrase starting with "every" that
+// domain.ParseRecurrence accepts
+func (p *parser) parseRecurrence() {
+	words := p.result.words
+	for i := range words {
+		if words[i].key != "every" || p.taken(i) {
+			continue
+		}
+
+		for j := min(len(words), i+maxRecurrenceWords); j > i+1; j-- {
+			if p.anyTaken(i, j) || recurrenceFillers[words[j-1].key] {
+				continue
+			}
+			phrase := p.keys(i, j)
+			if _, err := domain.ParseRecurrence(phrase); err == nil {
+				p.result.Recurrence = phrase
+				p.extract(SpanRecurrence, i, j)
+				return
+			}
+		}
+	}
+}
+
+func (p *parser) anyTaken(from, to int) bool {
+	for i := from; i < to; i++ {
+		if p.taken(i) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) keys(from, to int) string {
+	keys := make([]string, 0, to-from)
+	for _, w := range p.result.words[from:to] {
+		keys = append(keys, w.key)
+	}
+	return strings.Join(keys, " ")
+}
+
+// parseDue extracts the first date and the first time of day, then
+// combines them into the due date. Without a date a recurring task is due
+// on its first occurrence and a time alone means its next occurrence.
+func (p *parser) parseDue() {
+	words := p.result.words
+	for i := 0; i < len(words); i++ {
+		if p.taken(i) {
+			continue
+		}
+		if p.date == nil {
+			if n := p.matchDate(i); n > 0 {
+				p.extract(SpanDue, i, i+n)
+				i += n - 1
+				continue
+			}
+		}
+		if !p.hasTime {
+			if n := p.matchTime(i); n > 0 {
+				p.extract(SpanDue, i, i+n)
+				i += n - 1
+			}
+		}
+	}
+
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+	date := p.date
+	if date == nil && p.result.Recurrence != "" {
+		rule, err := domain.ParseRecurrence(p.result.Recurrence)
+		if err == nil {
+			if first, ok := rule.FirstOccurrence(today); ok {
+				date = &first
+			}
+		}
+	}
+	if date == nil && p.hasTime {
+		date = &today
+		if !p.at(today).After(p.now) {
+			tomorrow := today.AddDate(0, 0, 1)
+			date = &tomorrow
+		}
+	}
+	if date == nil {
+		return
+	}
+
+	due := *date
+	if p.hasTime {
+		due = p.at(due)
+	}
+	p.result.Due = &due
+	p.result.DueHasTime = p.hasTime
+}
+
+// at returns the parsed time of day on the given day
+func (p *parser) at(day time.Time) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), p.hour, p.minute, 0, 0, day.Location())
+}
+
+// word returns the key of words[i], empty past the end
+func (p *parser) word(i int) string {
+	if i < 0 || i >= len(p.result.words) || p.taken(i) {
+		return ""
+	}
+	return p.result.words[i].key
+}
+
+// matchDate reads a date at words[i], setting p.date, and returns the
+// number of words it spans. A date may be followed by a time of day.
+func (p *parser) matchDate(i int) int {
+	n := 0
+	if p.word(i) == "on" {
+		n = 1
+	}
+
+	date, length := p.dateAt(i + n)
+	if length == 0 {
+		return 0
+	}
+	p.date = &date
+	n += length
+
+	if !p.hasTime {
+		n += p.matchTime(i + n)
+	}
+	return n
+}
+
+func (p *parser) dateAt(i int) (time.Time, int) {
+	loc := p.now.Location()
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, loc)
+	key := p.word(i)
+
+	switch key {
+	case "":
+		return time.Time{}, 0
+	case "today":
+		return today, 1
+	case "tomorrow", "tmrw":
+		return today.AddDate(0, 0, 1), 1
+	case "next":
+		nextWeek := today.AddDate(0, 0, 7-mondayIndex(today.Weekday()))
+		next := p.word(i + 1)
+		if weekday, ok := weekdays[next]; ok {
+			return nextWeek.AddDate(0, 0, mondayIndex(weekday)), 2
+		}
+		switch next {
+		case "week":
+			return nextWeek, 2
+		case "month":
+			return time.Date(today.Year(), today.Month()+1, 1, 0, 0, 0, 0, loc), 2
+		}
+		return time.Time{}, 0
+	case "in":
+		count, ok := counts[p.word(i+1)]
+		if !ok {
+			var err error
+			count, err = strconv.Atoi(p.word(i + 1))
+			if err != nil || count < 1 {
+				return time.Time{}, 0
+			}
+		}
+		switch p.word(i + 2) {
+		case "day", "days":
+			return today.AddDate(0, 0, count), 3
+		case "week", "weeks":
+			return today.AddDate(0, 0, 7*count), 3
+		case "month", "months":
+			return today.AddDate(0, count, 0), 3
+		}
+		return time.Time{}, 0
+	}
+
+	if weekday, ok := weekdays[key]; ok {
+		days := (int(weekday) - int(today.Weekday()) + 7) % 7
+		return today.AddDate(0, 0, days), 1
+	}
+
+	if m := isoDatePattern.FindStringSubmatch(key); m != nil {
+		date, err := time.ParseInLocation("2006-01-02", key, loc)
+		if err != nil {
+			return time.Time{}, 0
+		}
+		return date, 1
+	}
+
+	// "jan 15", "15 jan" and "january 15th 2025"
+	month, day, n := time.Month(0), 0, 0
+	if m, ok := months[key]; ok {
+		if d, ok := dayOfMonth(p.word(i + 1)); ok {
+			month, day, n = m, d, 2
+		}
+	} else if d, ok := dayOfMonth(key); ok {
+		if m, ok := months[p.word(i+1)]; ok {
+			month, day, n = m, d, 2
+		}
+	}
+	if n == 0 {
+		return time.Time{}, 0
+	}
+
+	year := today.Year()
+	explicitYear := yearPattern.MatchString(p.word(i + n))
+	if explicitYear {
+		year, _ = strconv.Atoi(p.word(i + n))
+		n++
+	}
+	date := time.Date(year, month, day, 0, 0, 0, 0, loc)
+	if date.Day() != day {
+		return time.Time{}, 0 // "feb 30"
+	}
+	if !explicitYear && date.Before(today) {
+		date = date.AddDate(1, 0, 0)
+	}
+	return date, n
+}
+
+// matchTime reads a time of day such as "5pm", "at 17:30", "5 pm" or
+// "noon" at words[i] and returns the number of words it spans
+func (p *parser) matchTime(i int) int {
+	n := 0
+	if p.word(i) == "at" {
+		n = 1
+	}
+
+	key := p.word(i + n)
+	switch key {
+	case "":
+		return 0
+	case "noon":
+		p.setTime(12, 0)
+		return n + 1
+	case "midnight":
+		p.setTime(0, 0)
+		return n + 1
+	}
+
+	m := clockPattern.FindStringSubmatch(key)
+	if m == nil {
+		return 0
+	}
+	length := 1
+	suffix := m[3]
+	if suffix == "" {
+		if next := p.word(i + n + 1); next == "am" || next == "pm" {
+			suffix = next
+			length = 2
+		}
+	}
+	// A bare number is not a time; "17:30" is
+	if suffix == "" && m[2] == "" {
+		return 0
+	}
+
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	switch {
+	case minute > 59:
+		return 0
+	case suffix == "" && hour > 23:
+		return 0
+	case suffix != "" && (hour < 1 || hour > 12):
+		return 0
+	case suffix == "am" && hour == 12:
+		hour = 0
+	case suffix == "pm" && hour < 12:
+		hour += 12
+	}
+
+	p.setTime(hour, minute)
+	return n + length
+}
+
+func (p *parser) setTime(hour, minute int) {
+	p.hour, p.minute, p.hasTime = hour, minute, true
+}
+
+// dayOfMonth parses "15" or "15th"
+func dayOfMonth(key string) (int, bool) {
+	key = strings.TrimRight(key, "stndrh")
+	day, err := strconv.Atoi(key)
+	if err != nil || day < 1 || day > 31 {
+		return 0, false
+	}
+	return day, true
+}
+
+// mondayIndex counts weekdays from Monday, the first day of the week
+func mondayIndex(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
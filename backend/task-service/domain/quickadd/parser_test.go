@@ -0,0 +1,112 @@
+package quickadd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	// Wednesday
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	day := func(month time.Month, d, hour, minute int) *time.Time {
+		t := time.Date(2024, month, d, hour, minute, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		text       string
+		title      string
+		due        *time.Time
+		hasTime    bool
+		recurrence string
+		priority   int
+		project    string
+		labels     []string
+	}{
+		{text: "Buy milk", title: "Buy milk"},
+		{text: "Buy milk today", title: "Buy milk", due: day(5, 15, 0, 0)},
+		{text: "Buy milk tomorrow at 5pm", title: "Buy milk", due: day(5, 16, 17, 0), hasTime: true},
+		{text: "Call mom next monday", title: "Call mom", due: day(5, 20, 0, 0)},
+		{text: "Call mom friday 17:30", title: "Call mom", due: day(5, 17, 17, 30), hasTime: true},
+		{text: "Renew passport in 2 weeks", title: "Renew passport", due: day(5, 29, 0, 0)},
+		{text: "Taxes on 2024-06-01", title: "Taxes", due: day(6, 1, 0, 0)},
+		{text: "Birthday jan 15", title: "Birthday", due: func() *time.Time {
+			t := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+			return &t
+		}()},
+		{text: "Standup 9am", title: "Standup", due: day(5, 16, 9, 0), hasTime: true},
+		{text: "Lunch at noon", title: "Lunch", due: day(5, 15, 12, 0), hasTime: true},
+		{
+			text:       "Pay rent every month on the 1st p1 #Home @bills",
+			title:      "Pay rent",
+			due:        day(6, 1, 0, 0),
+			recurrence: "every month on the 1st",
+			priority:   1,
+			project:    "Home",
+			labels:     []string{"bills"},
+		},
+		{text: "Water plants every monday and friday", title: "Water plants", due: day(5, 17, 0, 0), recurrence: "every monday and friday"},
+		{text: "Gym every day and", title: "Gym and", due: day(5, 15, 0, 0), recurrence: "every day"},
+		{text: "Report p2 p3 #Work #Home", title: "Report p3 #Home", priority: 2, project: "Work"},
+		{text: "Read 5 books", title: "Read 5 books"},
+		{text: "Meet feb 30", title: "Meet feb 30"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			got := Parse(tt.text, now)
+			if title := got.Title(); title != tt.title {
+				t.Errorf("Title() = %q, want %q", title, tt.title)
+			}
+			if (got.Due == nil) != (tt.due == nil) || (got.Due != nil && !got.Due.Equal(*tt.due)) {
+				t.Errorf("Due = %v, want %v", got.Due, tt.due)
+			}
+			if got.DueHasTime != tt.hasTime {
+				t.Errorf("DueHasTime = %v, want %v", got.DueHasTime, tt.hasTime)
+			}
+			if got.Recurrence != tt.recurrence {
+				t.Errorf("Recurrence = %q, want %q", got.Recurrence, tt.recurrence)
+			}
+			if got.PriorityLevel != tt.priority {
+				t.Errorf("PriorityLevel = %d, want %d", got.PriorityLevel, tt.priority)
+			}
+			if got.Project != tt.project {
+				t.Errorf("Project = %q, want %q", got.Project, tt.project)
+			}
+			if !reflect.DeepEqual(got.Labels, tt.labels) {
+				t.Errorf("Labels = %q, want %q", got.Labels, tt.labels)
+			}
+		})
+	}
+}
+
+func TestParseSpans(t *testing.T) {
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	got := Parse("Café tomorrow p1 #Home", now)
+
+	want := []Span{
+		{Kind: SpanDue, Start: 5, End: 13, Text: "tomorrow"},
+		{Kind: SpanPriority, Start: 14, End: 16, Text: "p1"},
+		{Kind: SpanProject, Start: 17, End: 22, Text: "#Home"},
+	}
+	if !reflect.DeepEqual(got.Spans, want) {
+		t.Errorf("Spans = %+v, want %+v", got.Spans, want)
+	}
+}
+
+func TestResultUnextract(t *testing.T) {
+	now := time.Date(2024, 5, 15, 10, 0, 0, 0, time.UTC)
+	got := Parse("Plan trip #Travel tomorrow", now)
+	got.Unextract(SpanProject)
+
+	if got.Project != "" {
+		t.Errorf("Project = %q, want none", got.Project)
+	}
+	if title := got.Title(); title != "Plan trip #Travel" {
+		t.Errorf("Title() = %q, want %q", title, "Plan trip #Travel")
+	}
+	if got.Due == nil {
+		t.Error("Due was dropped with the project")
+	}
+}
@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// rankDigits are the digits of a position rank in ascending byte order.
+// Ranks compare as plain strings, so any two of them always have room for
+// another in between and reordering a task never rewrites its neighbours.
+const rankDigits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+var ErrInvalidRank = errors.New("invalid position rank")
+
+// RankBetween returns a rank that sorts strictly between before and after.
+// An empty before stands for the start of the list and an empty after for
+// its end.
+func RankBetween(before, after string) (string, error) {
+	if !validRank(before) || !validRank(after) {
+		return "", ErrInvalidRank
+	}
+	if after != "" && before >= after {
+		return "", ErrInvalidRank
+	}
+	return rankMidpoint(before, after), nil
+}
+
+// RanksAfter returns n ascending ranks that all sort after last
+func RanksAfter(last string, n int) ([]string, error) {
+	ranks := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		rank, err := RankBetween(last, "")
+		if err != nil {
+			return nil, err
+		}
+		ranks = append(ranks, rank)
+		last = rank
+	}
+	return ranks, nil
+}
+
+// validRank accepts ranks made of rank digits without a trailing zero,
+// which would leave no room for a rank right before them
+func validRank(rank string) bool {
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(rankDigits, rank[i]) < 0 {
+			return false
+		}
+	}
+	return !strings.HasSuffix(rank, "0")
+}
+
+// rankMidpoint finds a short rank between a and b, where a < b and an empty
+// b is unbounded. A missing digit of a counts as zero.
+func rankMidpoint(a, b string) string {
+	if b != "" {
+		// Keep the common prefix and split the remainder
+		n := 0
+		for n < len(b) && rankDigitAt(a, n) == b[n] {
+			n++
+		}
+		if n > 0 {
+			return b[:n] + rankMidpoint(rankSuffix(a, n), b[n:])
+		}
+	}
+
+	lo := 0
+	if a != "" {
+		lo = strings.IndexByte(rankDigits, a[0])
+	}
+	hi := len(rankDigits)
+	if b != "" {
+		hi = strings.IndexByte(rankDigits, b[0])
+	}
+	if hi-lo > 1 {
+		return string(rankDigits[(lo+hi)/2])
+	}
+
+	// The leading digits are adjacent: a longer b can be cut short,
+	// otherwise keep a's digit and go one level deeper
+	if len(b) > 1 {
+		return b[:1]
+	}
+	return string(rankDigits[lo]) + rankMidpoint(rankSuffix(a, 1), "")
+}
+
+func rankDigitAt(rank string, i int) byte {
+	if i < len(rank) {
+		return rank[i]
+	}
+	return rankDigits[0]
+}
+
+func rankSuffix(rank string, i int) string {
+	if i < len(rank) {
+		return rank[i:]
+	}
+	return ""
+}
@@ -0,0 +1,113 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestRankBetween(t *testing.T) {
+	tests := []struct {
+		before string
+		after  string
+	}{
+		{"", ""},
+		{"", "i"},
+		{"i", ""},
+		{"a", "b"},
+		{"a", "a1"},
+		{"az", "b"},
+		{"1", "2"},
+		{"", "01"},
+		{"zz", ""},
+		{"i", "i00001"},
+		{"hzzzz", "i"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.before+"_"+tt.after, func(t *testing.T) {
+			got, err := RankBetween(tt.before, tt.after)
+			if err != nil {
+				t.Fatalf("RankBetween(%q, %q) error: %v", tt.before, tt.after, err)
+			}
+			if got <= tt.before || (tt.after != "" && got >= tt.after) {
+				t.Errorf("RankBetween(%q, %q) = %q, not between them", tt.before, tt.after, got)
+			}
+			if !validRank(got) {
+				t.Errorf("RankBetween(%q, %q) = %q, not a valid rank", tt.before, tt.after, got)
+			}
+		})
+	}
+}
+
+func TestRankBetweenEmptyList(t *testing.T) {
+	got, err := RankBetween("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "i" {
+		t.Errorf("RankBetween(\"\", \"\") = %q, want %q", got, "i")
+	}
+}
+
+func TestRankBetweenInvalid(t *testing.T) {
+	tests := []struct {
+		before string
+		after  string
+	}{
+		{"b", "a"},
+		{"a", "a"},
+		{"a0", ""},
+		{"", "b0"},
+		{"A", ""},
+		{"", "a-b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.before+"_"+tt.after, func(t *testing.T) {
+			_, err := RankBetween(tt.before, tt.after)
+			if !errors.Is(err, ErrInvalidRank) {
+				t.Errorf("RankBetween(%q, %q) error = %v, want ErrInvalidRank", tt.before, tt.after, err)
+			}
+		})
+	}
+}
+
+func TestRankBetweenRepeatedInserts(t *testing.T) {
+	// Inserting again and again at the same spot must keep finding room
+	before, after := "a", "b"
+	for i := 0; i < 200; i++ {
+		rank, err := RankBetween(before, after)
+		if err != nil {
+			t.Fatalf("insert %d: RankBetween(%q, %q) error: %v", i, before, after, err)
+		}
+		if rank <= before || rank >= after {
+			t.Fatalf("insert %d: RankBetween(%q, %q) = %q, not between them", i, before, after, rank)
+		}
+		if i%2 == 0 {
+			after = rank
+		} else {
+			before = rank
+		}
+	}
+}
+
+func TestRanksAfter(t *testing.T) {
+	ranks, err := RanksAfter("z", 50)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ranks) != 50 {
+		t.Fatalf("RanksAfter returned %d ranks, want 50", len(ranks))
+	}
+	last := "z"
+	for _, rank := range ranks {
+		if rank <= last {
+			t.Errorf("rank %q does not sort after %q", rank, last)
+		}
+		if strings.HasSuffix(rank, "0") {
+			t.Errorf("rank %q ends in a zero", rank)
+		}
+		last = rank
+	}
+}
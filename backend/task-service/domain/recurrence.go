@@ -0,0 +1,506 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// maxRecurrencePeriods bounds the search for the next occurrence so that a
+// rule which can never match does not loop forever
+const maxRecurrencePeriods = 5000
+
+var ErrInvalidRecurrence = errors.New("invalid recurrence rule")
+
+// RecurrenceDay is a BYDAY entry. N selects the nth weekday of the month
+// (counting from the end when negative); 0 means every such weekday.
+type RecurrenceDay struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// RecurrenceRule is the subset of an RFC 5545 RRULE supported for tasks
+type RecurrenceRule struct {
+	Freq       Frequency
+	Interval   int
+	ByDay      []RecurrenceDay
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thur": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+	"sunday": time.Sunday, "sun": time.Sunday,
+}
+
+var ordinalWords = map[string]int{
+	"first": 1, "second": 2, "third": 3, "fourth": 4, "fifth": 5, "last": -1,
+}
+
+// ParseRecurrence parses either an RFC 5545 RRULE ("FREQ=WEEKLY;BYDAY=MO")
+// or a Todoist-style phrase ("every 2nd monday", "every other week")
+func ParseRecurrence(text string) (*RecurrenceRule, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRecurrence)
+	}
+
+	var (
+		rule *RecurrenceRule
+		err  error
+	)
+	upper := strings.ToUpper(text)
+	if strings.HasPrefix(upper, "RRULE:") || strings.Contains(upper, "FREQ=") {
+		rule, err = parseRRule(strings.TrimPrefix(upper, "RRULE:"))
+	} else {
+		rule, err = parseNaturalRecurrence(text)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := rule.validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func parseRRule(text string) (*RecurrenceRule, error) {
+	rule := &RecurrenceRule{Interval: 1}
+
+	for _, part := range strings.Split(text, ";") {
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRecurrence, part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch Frequency(value) {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+				rule.Freq = Frequency(value)
+			default:
+				return nil, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRecurrence, value)
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive integer", ErrInvalidRecurrence)
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be a positive integer", ErrInvalidRecurrence)
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRRuleTime(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: malformed UNTIL %q", ErrInvalidRecurrence, value)
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, err := parseRRuleDay(code)
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			for _, v := range strings.Split(value, ",") {
+				day, err := strconv.Atoi(v)
+				if err != nil {
+					return nil, fmt.Errorf("%w: malformed BYMONTHDAY %q", ErrInvalidRecurrence, v)
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, day)
+			}
+		case "WKST":
+			// Weeks always start on Monday
+		default:
+			return nil, fmt.Errorf("%w: unsupported part %q", ErrInvalidRecurrence, key)
+		}
+	}
+
+	return rule, nil
+}
+
+func parseRRuleDay(code string) (RecurrenceDay, error) {
+	code = strings.TrimSpace(code)
+	if len(code) < 2 {
+		return RecurrenceDay{}, fmt.Errorf("%w: malformed BYDAY %q", ErrInvalidRecurrence, code)
+	}
+
+	weekday, ok := weekdayCodes[code[len(code)-2:]]
+	if !ok {
+		return RecurrenceDay{}, fmt.Errorf("%w: malformed BYDAY %q", ErrInvalidRecurrence, code)
+	}
+
+	day := RecurrenceDay{Weekday: weekday}
+	if prefix := code[:len(code)-2]; prefix != "" {
+		n, err := strconv.Atoi(prefix)
+		if err != nil || n == 0 {
+			return RecurrenceDay{}, fmt.Errorf("%w: malformed BYDAY %q", ErrInvalidRecurrence, code)
+		}
+		day.N = n
+	}
+	return day, nil
+}
+
+func parseRRuleTime(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q", value)
+}
+
+// parseNaturalRecurrence understands phrases such as "every day",
+// "every 3 weeks", "every other month", "every monday and friday",
+// "every weekday", "every 2nd monday", "every last day" and
+// "every month on the 15th"
+func parseNaturalRecurrence(text string) (*RecurrenceRule, error) {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+
+	rule := &RecurrenceRule{Interval: 1}
+	if len(words) == 1 {
+		switch words[0] {
+		case "daily":
+			rule.Freq = FrequencyDaily
+		case "weekly":
+			rule.Freq = FrequencyWeekly
+		case "monthly":
+			rule.Freq = FrequencyMonthly
+		case "yearly", "annually":
+			rule.Freq = FrequencyYearly
+		}
+		if rule.Freq != "" {
+			return rule, nil
+		}
+	}
+
+	if len(words) < 2 || words[0] != "every" {
+		return nil, fmt.Errorf("%w: %q should start with \"every\"", ErrInvalidRecurrence, text)
+	}
+
+	setFreq := func(freq Frequency) error {
+		if rule.Freq != "" && rule.Freq != freq {
+			return fmt.Errorf("%w: conflicting periods in %q", ErrInvalidRecurrence, text)
+		}
+		rule.Freq = freq
+		return nil
+	}
+
+	for i := 1; i < len(words); i++ {
+		word := words[i]
+		var err error
+
+		switch word {
+		case "and", "on", "the", "of":
+			continue
+		case "other":
+			rule.Interval = 2
+		case "day", "days":
+			err = setFreq(FrequencyDaily)
+		case "week", "weeks":
+			err = setFreq(FrequencyWeekly)
+		case "month", "months":
+			err = setFreq(FrequencyMonthly)
+		case "year", "years":
+			err = setFreq(FrequencyYearly)
+		case "weekday", "weekdays", "workday", "workdays":
+			for _, wd := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday} {
+				rule.ByDay = append(rule.ByDay, RecurrenceDay{Weekday: wd})
+			}
+			err = setFreq(FrequencyWeekly)
+		case "weekend", "weekends":
+			rule.ByDay = append(rule.ByDay, RecurrenceDay{Weekday: time.Saturday}, RecurrenceDay{Weekday: time.Sunday})
+			err = setFreq(FrequencyWeekly)
+		default:
+			if weekday, ok := weekdayNames[strings.TrimSuffix(word, "s")]; ok {
+				rule.ByDay = append(rule.ByDay, RecurrenceDay{Weekday: weekday})
+				if rule.Freq == "" {
+					rule.Freq = FrequencyWeekly
+				}
+				continue
+			}
+
+			if n, err := strconv.Atoi(word); err == nil {
+				if n < 1 {
+					return nil, fmt.Errorf("%w: interval must be positive", ErrInvalidRecurrence)
+				}
+				rule.Interval = n
+				continue
+			}
+
+			n, ok := parseOrdinal(word)
+			if !ok {
+				return nil, fmt.Errorf("%w: unexpected %q", ErrInvalidRecurrence, word)
+			}
+
+			// "2nd monday" selects a weekday of the month, "last day" and
+			// "15th" select a day of the month
+			var next string
+			if i+1 < len(words) {
+				next = words[i+1]
+			}
+			if weekday, ok := weekdayNames[next]; ok {
+				rule.ByDay = append(rule.ByDay, RecurrenceDay{Weekday: weekday, N: n})
+				i++
+			} else {
+				if next == "day" {
+					i++
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, n)
+			}
+			if rule.Freq == "" || rule.Freq == FrequencyWeekly {
+				rule.Freq = FrequencyMonthly
+			}
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: no period in %q", ErrInvalidRecurrence, text)
+	}
+	return rule, nil
+}
+
+func parseOrdinal(word string) (int, bool) {
+	if n, ok := ordinalWords[word]; ok {
+		return n, true
+	}
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if strings.HasSuffix(word, suffix) {
+			n, err := strconv.Atoi(strings.TrimSuffix(word, suffix))
+			if err == nil && n >= 1 && n <= 31 {
+				return n, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (r *RecurrenceRule) validate() error {
+	if r.Freq == "" {
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRecurrence)
+	}
+	if r.Interval < 1 {
+		r.Interval = 1
+	}
+	if r.Count > 0 && r.Until != nil {
+		return fmt.Errorf("%w: COUNT and UNTIL are mutually exclusive", ErrInvalidRecurrence)
+	}
+	for _, day := range r.ByDay {
+		if day.N != 0 && r.Freq != FrequencyMonthly {
+			return fmt.Errorf("%w: ordinal weekdays require a monthly rule", ErrInvalidRecurrence)
+		}
+		if day.N < -5 || day.N > 5 {
+			return fmt.Errorf("%w: weekday ordinal out of range", ErrInvalidRecurrence)
+		}
+	}
+	if len(r.ByMonthDay) > 0 && r.Freq != FrequencyMonthly {
+		return fmt.Errorf("%w: days of the month require a monthly rule", ErrInvalidRecurrence)
+	}
+	for _, day := range r.ByMonthDay {
+		if day == 0 || day < -31 || day > 31 {
+			return fmt.Errorf("%w: day of the month out of range", ErrInvalidRecurrence)
+		}
+	}
+	return nil
+}
+
+// String formats the rule as an RFC 5545 RRULE value
+func (r *RecurrenceRule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			code := strings.ToUpper(day.Weekday.String()[:2])
+			if day.N != 0 {
+				code = strconv.Itoa(day.N) + code
+			}
+			codes[i] = code
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		days := make([]string, len(r.ByMonthDay))
+		for i, day := range r.ByMonthDay {
+			days[i] = strconv.Itoa(day)
+		}
+		parts = append(parts, "BYMONTHDAY="+strings.Join(days, ","))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Next returns the first occurrence strictly after `after` of the series
+// starting at `from`. It returns false when the series has ended.
+func (r *RecurrenceRule) Next(from, after time.Time) (time.Time, bool) {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		for _, candidate := range r.occurrencesInPeriod(from, period*interval) {
+			if !candidate.After(after) {
+				continue
+			}
+			if r.Until != nil && candidate.After(*r.Until) {
+				return time.Time{}, false
+			}
+			return candidate, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// FirstOccurrence returns the first occurrence on or after from
+func (r *RecurrenceRule) FirstOccurrence(from time.Time) (time.Time, bool) {
+	return r.Next(from, from.Add(-time.Nanosecond))
+}
+
+// occurrencesInPeriod lists, in order, the occurrences in the period that is
+// offset periods of the rule's frequency away from the period containing from
+func (r *RecurrenceRule) occurrencesInPeriod(from time.Time, offset int) []time.Time {
+	hour, minute, sec := from.Clock()
+	at := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, hour, minute, sec, from.Nanosecond(), from.Location())
+	}
+
+	switch r.Freq {
+	case FrequencyDaily:
+		day := from.AddDate(0, 0, offset)
+		if len(r.ByDay) > 0 && !r.matchesWeekday(day.Weekday()) {
+			return nil
+		}
+		return []time.Time{day}
+
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			return []time.Time{from.AddDate(0, 0, 7*offset)}
+		}
+		monday := from.AddDate(0, 0, -(int(from.Weekday())+6)%7+7*offset)
+		var occurrences []time.Time
+		for i := 0; i < 7; i++ {
+			day := monday.AddDate(0, 0, i)
+			if r.matchesWeekday(day.Weekday()) {
+				occurrences = append(occurrences, day)
+			}
+		}
+		return occurrences
+
+	case FrequencyMonthly:
+		first := time.Date(from.Year(), from.Month()+time.Month(offset), 1, 0, 0, 0, 0, from.Location())
+		year, month := first.Year(), first.Month()
+		daysInMonth := first.AddDate(0, 1, -1).Day()
+
+		var days []int
+		for _, n := range r.ByMonthDay {
+			if n < 0 {
+				n = daysInMonth + n + 1
+			}
+			if n >= 1 && n <= daysInMonth {
+				days = append(days, n)
+			}
+		}
+		for _, byDay := range r.ByDay {
+			days = append(days, weekdaysInMonth(year, month, daysInMonth, byDay)...)
+		}
+		if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 && from.Day() <= daysInMonth {
+			days = append(days, from.Day())
+		}
+
+		sort.Ints(days)
+		var occurrences []time.Time
+		for i, day := range days {
+			if i > 0 && days[i-1] == day {
+				continue
+			}
+			occurrences = append(occurrences, at(year, month, day))
+		}
+		return occurrences
+
+	case FrequencyYearly:
+		day := at(from.Year()+offset, from.Month(), from.Day())
+		// Skip years without the date, e.g. February 29th
+		if day.Day() != from.Day() {
+			return nil
+		}
+		return []time.Time{day}
+	}
+
+	return nil
+}
+
+func (r *RecurrenceRule) matchesWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// weekdaysInMonth returns the days of the month matching a BYDAY entry
+func weekdaysInMonth(year int, month time.Month, daysInMonth int, byDay RecurrenceDay) []int {
+	var days []int
+	for day := 1; day <= daysInMonth; day++ {
+		if time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() == byDay.Weekday {
+			days = append(days, day)
+		}
+	}
+
+	switch {
+	case byDay.N == 0:
+		return days
+	case byDay.N > 0 && byDay.N <= len(days):
+		return []int{days[byDay.N-1]}
+	case byDay.N < 0 && -byDay.N <= len(days):
+		return []int{days[len(days)+byDay.N]}
+	}
+	return nil
+}
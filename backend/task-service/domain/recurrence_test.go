@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"daily", "FREQ=DAILY"},
+		{"every day", "FREQ=DAILY"},
+		{"every 3 weeks", "FREQ=WEEKLY;INTERVAL=3"},
+		{"every other month", "FREQ=MONTHLY;INTERVAL=2"},
+		{"Every Monday and Friday", "FREQ=WEEKLY;BYDAY=MO,FR"},
+		{"every weekday", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR"},
+		{"every 2nd monday", "FREQ=MONTHLY;BYDAY=2MO"},
+		{"every last day", "FREQ=MONTHLY;BYMONTHDAY=-1"},
+		{"every month on the 15th", "FREQ=MONTHLY;BYMONTHDAY=15"},
+		{"FREQ=WEEKLY;BYDAY=MO", "FREQ=WEEKLY;BYDAY=MO"},
+		{"RRULE:FREQ=MONTHLY;BYDAY=-1FR", "FREQ=MONTHLY;BYDAY=-1FR"},
+		{"freq=daily;interval=2;until=20250101", "FREQ=DAILY;INTERVAL=2;UNTIL=20250101T000000Z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.text)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) error: %v", tt.text, err)
+			}
+			if got := rule.String(); got != tt.want {
+				t.Errorf("ParseRecurrence(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceInvalid(t *testing.T) {
+	tests := []string{
+		"",
+		"sometimes",
+		"every",
+		"every blue moon",
+		"every day week",
+		"every 0 days",
+		"FREQ=HOURLY",
+		"FREQ=WEEKLY;BYDAY=2MO",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=DAILY;COUNT=2;UNTIL=20250101T000000Z",
+		"INTERVAL=2",
+	}
+
+	for _, text := range tests {
+		t.Run(text, func(t *testing.T) {
+			_, err := ParseRecurrence(text)
+			if !errors.Is(err, ErrInvalidRecurrence) {
+				t.Errorf("ParseRecurrence(%q) error = %v, want ErrInvalidRecurrence", text, err)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleNext(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, 5, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule  string
+		after time.Time
+		want  time.Time
+		ok    bool
+	}{
+		{"every day", from, time.Date(2024, 5, 16, 9, 0, 0, 0, time.UTC), true},
+		{"every other week", from, time.Date(2024, 5, 29, 9, 0, 0, 0, time.UTC), true},
+		{"every monday and friday", from, time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC), true},
+		{"every monday and friday", time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC), time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC), true},
+		{"every weekday", time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC), time.Date(2024, 5, 20, 9, 0, 0, 0, time.UTC), true},
+		{"every 2nd monday", from, time.Date(2024, 6, 10, 9, 0, 0, 0, time.UTC), true},
+		{"every last day", from, time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC), true},
+		{"every last day", time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC), time.Date(2024, 6, 30, 9, 0, 0, 0, time.UTC), true},
+		{"every month on the 31st", time.Date(2024, 5, 31, 9, 0, 0, 0, time.UTC), time.Date(2024, 7, 31, 9, 0, 0, 0, time.UTC), true},
+		{"every year", from, time.Date(2025, 5, 15, 9, 0, 0, 0, time.UTC), true},
+		{"FREQ=DAILY;UNTIL=20240517T090000Z", time.Date(2024, 5, 16, 9, 0, 0, 0, time.UTC), time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC), true},
+		{"FREQ=DAILY;UNTIL=20240517T090000Z", time.Date(2024, 5, 17, 9, 0, 0, 0, time.UTC), time.Time{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) error: %v", tt.rule, err)
+			}
+			got, ok := rule.Next(from, tt.after)
+			if ok != tt.ok || !got.Equal(tt.want) {
+				t.Errorf("Next(%v) = %v, %v, want %v, %v", tt.after, got, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleNextKeepsWallClock(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+	rule, err := ParseRecurrence("every day")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Daylight saving time starts on March 31st 2024 in Berlin
+	from := time.Date(2024, 3, 30, 9, 0, 0, 0, loc)
+	got, ok := rule.Next(from, from)
+	want := time.Date(2024, 3, 31, 9, 0, 0, 0, loc)
+	if !ok || !got.Equal(want) {
+		t.Errorf("Next = %v, %v, want %v", got, ok, want)
+	}
+}
+
+func TestRecurrenceRuleFirstOccurrence(t *testing.T) {
+	// Wednesday
+	from := time.Date(2024, 5, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule string
+		want time.Time
+	}{
+		{"every day", from},
+		{"every wednesday", from},
+		{"every friday", time.Date(2024, 5, 17, 0, 0, 0, 0, time.UTC)},
+		{"every month on the 1st", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := ParseRecurrence(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) error: %v", tt.rule, err)
+			}
+			got, ok := rule.FirstOccurrence(from)
+			if !ok || !got.Equal(tt.want) {
+				t.Errorf("FirstOccurrence = %v, %v, want %v", got, ok, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// Reminder notifies the user about a task, either at a fixed time
+// (RemindAt) or an offset before the task is due (MinutesBefore)
+type Reminder struct {
+	ID            string
+	TaskID        string
+	UserID        string
+	RemindAt      *time.Time
+	MinutesBefore *int
+	// FireAt is when the reminder fires next, nil when nothing is
+	// scheduled: it has fired, or it is relative and the task has no due
+	// time
+	FireAt    *time.Time
+	FiredAt   *time.Time // last time it fired
+	CreatedAt time.Time
+}
+
+// IsRelative reports whether the reminder follows the task's due date
+func (r *Reminder) IsRelative() bool {
+	return r.MinutesBefore != nil
+}
+
+// Schedule sets FireAt for the task as it is now. A relative reminder
+// follows the task's due date, and only timed due dates have a moment to be
+// relative to; an absolute reminder keeps its RemindAt until it fires. A
+// moment that has already passed is not scheduled, so moving the due date
+// or reopening the task never fires a reminder late.
+func (r *Reminder) Schedule(task *Task, now time.Time) {
+	r.FireAt = nil
+	if !r.IsRelative() {
+		if r.FiredAt == nil && r.RemindAt.After(now) {
+			remindAt := *r.RemindAt
+			r.FireAt = &remindAt
+		}
+		return
+	}
+	if task.DueDate == nil || task.DueAllDay {
+		return
+	}
+	fireAt := task.DueDate.Add(-time.Duration(*r.MinutesBefore) * time.Minute)
+	if fireAt.After(now) {
+		r.FireAt = &fireAt
+	}
+}
+
+// ReminderRepository persists reminders. Reminders of completed and trashed
+// tasks stay stored but are never due; completing a task unschedules them.
+type ReminderRepository interface {
+	Create(reminder *Reminder) error
+	GetByID(id string) (*Reminder, error)
+	GetByTaskID(taskID string) ([]*Reminder, error)
+	Delete(id string) error
+	// Reschedule stores the FireAt of the given reminders
+	Reschedule(reminders []*Reminder) error
+	// UnscheduleSubtree clears the FireAt of the reminders of a task and
+	// its descendants
+	UnscheduleSubtree(rootID string) error
+	// ClaimDue locks up to limit reminders due at now, earliest first, for
+	// the rest of the transaction. Reminders locked by another transaction
+	// are skipped, so concurrent schedulers claim disjoint sets.
+	ClaimDue(now time.Time, limit int) ([]*Reminder, error)
+	// MarkFired records that a claimed reminder fired and unschedules it
+	MarkFired(id string, firedAt time.Time) error
+}
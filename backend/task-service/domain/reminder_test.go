@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReminderSchedule(t *testing.T) {
+	now := time.Date(2024, 5, 17, 12, 0, 0, 0, time.UTC)
+	at := func(hour int) *time.Time {
+		moment := time.Date(2024, 5, 17, hour, 0, 0, 0, time.UTC)
+		return &moment
+	}
+	minutes := func(n int) *int { return &n }
+
+	tests := []struct {
+		name     string
+		reminder Reminder
+		task     Task
+		want     *time.Time
+	}{
+		{
+			name:     "relative to a timed due date",
+			reminder: Reminder{MinutesBefore: minutes(60)},
+			task:     Task{DueDate: at(15)},
+			want:     at(14),
+		},
+		{
+			name:     "relative moment already passed",
+			reminder: Reminder{MinutesBefore: minutes(60), FireAt: at(11)},
+			task:     Task{DueDate: at(12)},
+		},
+		{
+			name:     "relative to an all-day due date",
+			reminder: Reminder{MinutesBefore: minutes(60)},
+			task:     Task{DueDate: at(0), DueAllDay: true},
+		},
+		{
+			name:     "absolute still ahead",
+			reminder: Reminder{RemindAt: at(13)},
+			want:     at(13),
+		},
+		{
+			name:     "absolute came due while completed",
+			reminder: Reminder{RemindAt: at(9)},
+		},
+		{
+			name:     "absolute already fired",
+			reminder: Reminder{RemindAt: at(13), FiredAt: at(11)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.reminder.Schedule(&tt.task, now)
+			got := tt.reminder.FireAt
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("FireAt = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
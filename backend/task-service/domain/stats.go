@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"errors"
+	"sort"
+	"time"
+)
+
+// Default productivity goals, in completed tasks per day and per week
+const (
+	DefaultDailyGoal  = 5
+	DefaultWeeklyGoal = 25
+	MaxGoal           = 1000
+)
+
+// Karma earned by a completed occurrence: a base amount, a bonus per
+// priority above the lowest and a bonus for finishing it on time. Reaching
+// the daily or weekly goal earns a bonus once per day or week.
+const (
+	KarmaPerCompletion    = 5
+	KarmaPerPriorityLevel = 1
+	KarmaOnTimeBonus      = 2
+	KarmaDailyGoalBonus   = 10
+	KarmaWeeklyGoalBonus  = 50
+)
+
+// ErrInvalidGoal is returned for a goal outside 1..MaxGoal
+var ErrInvalidGoal = errors.New("goals must be between 1 and 1000 tasks")
+
+// UserStats are a user's productivity goals and running totals, updated
+// along with every completion
+type UserStats struct {
+	UserID         string
+	Karma          int
+	CompletedCount int
+	DailyGoal      int
+	WeeklyGoal     int
+	// Timezone is the IANA zone completions are counted into days in, empty
+	// for UTC. Changing it applies to later completions only.
+	Timezone  string
+	UpdatedAt time.Time
+}
+
+// DailyStats counts a user's completions on one day
+type DailyStats struct {
+	Day       time.Time // midnight UTC of the day in the user's time zone
+	Completed int
+}
+
+// Streak is a run of consecutive days or weeks that reached their goal.
+// Start and End are the first and last day or week, zero for no streak.
+type Streak struct {
+	Count int
+	Start time.Time
+	End   time.Time
+}
+
+// NewUserStats returns the stats of a user who has none yet
+func NewUserStats(userID string, now time.Time) *UserStats {
+	return &UserStats{
+		UserID:     userID,
+		DailyGoal:  DefaultDailyGoal,
+		WeeklyGoal: DefaultWeeklyGoal,
+		UpdatedAt:  now,
+	}
+}
+
+// SetGoals changes the daily and weekly goals
+func (s *UserStats) SetGoals(daily, weekly int) error {
+	if daily < 1 || daily > MaxGoal || weekly < 1 || weekly > MaxGoal {
+		return ErrInvalidGoal
+	}
+	s.DailyGoal, s.WeeklyGoal = daily, weekly
+	return nil
+}
+
+// Location returns the user's time zone, UTC when it is unset or unknown
+func (s *UserStats) Location() *time.Location {
+	if s.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// StatsDay returns the day t falls on in loc, as midnight UTC
+func StatsDay(t time.Time, loc *time.Location) time.Time {
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// StatsWeek returns the Monday starting the week of day
+func StatsWeek(day time.Time) time.Time {
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// CompletionKarma returns the karma earned by completion, an occurrence of
+// task completed in loc
+func CompletionKarma(task *Task, completion *TaskCompletion, loc *time.Location) int {
+	karma := KarmaPerCompletion
+	if task.Priority > 1 {
+		karma += (min(task.Priority, 4) - 1) * KarmaPerPriorityLevel
+	}
+	if completion.DueDate != nil {
+		occurrence := &Task{DueDate: completion.DueDate, DueAllDay: task.DueAllDay}
+		if !occurrence.IsOverdue(completion.CompletedAt.In(loc)) {
+			karma += KarmaOnTimeBonus
+		}
+	}
+	return karma
+}
+
+// DailyStreaks returns the streak of days reaching goal that is still
+// running on today, and the longest one. days are in ascending order; the
+// streak stays current through today, which is not over yet.
+func DailyStreaks(days []*DailyStats, goal int, today time.Time) (current, longest Streak) {
+	counts := make(map[time.Time]int, len(days))
+	for _, day := range days {
+		counts[day.Day] = day.Completed
+	}
+	return streaks(counts, goal, today, 1)
+}
+
+// WeeklyStreaks returns the current and longest streaks of weeks reaching
+// goal, like DailyStreaks
+func WeeklyStreaks(days []*DailyStats, goal int, today time.Time) (current, longest Streak) {
+	counts := make(map[time.Time]int)
+	for _, day := range days {
+		counts[StatsWeek(day.Day)] += day.Completed
+	}
+	return streaks(counts, goal, StatsWeek(today), 7)
+}
+
+// streaks finds the runs of periods, step days long and keyed by their
+// first day, whose count reaches goal. current is the run ending on the
+// period starting at now or the one before.
+func streaks(counts map[time.Time]int, goal int, now time.Time, step int) (current, longest Streak) {
+	var reached []time.Time
+	for start, count := range counts {
+		if count >= goal {
+			reached = append(reached, start)
+		}
+	}
+	sort.Slice(reached, func(i, j int) bool { return reached[i].Before(reached[j]) })
+
+	var run Streak
+	for _, start := range reached {
+		if run.Count > 0 && start.Equal(run.End.AddDate(0, 0, step)) {
+			run.Count++
+			run.End = start
+		} else {
+			run = Streak{Count: 1, Start: start, End: start}
+		}
+		if run.Count > longest.Count {
+			longest = run
+		}
+	}
+
+	if run.Count > 0 && !run.End.Before(now.AddDate(0, 0, -step)) {
+		current = run
+	}
+	return current, longest
+}
+
+// StatsRepository maintains the productivity aggregates. They are updated
+// in the transaction recording each completion, so reading stats never
+// scans tasks or their completions.
+type StatsRepository interface {
+	// Get returns the user's stats, and sql.ErrNoRows when the user has none
+	Get(userID string) (*UserStats, error)
+	// Lock returns the user's stats, created from defaults if missing, and
+	// locks them for the rest of the transaction so the user's completions
+	// are counted one at a time
+	Lock(defaults *UserStats) (*UserStats, error)
+	SaveGoals(stats *UserStats) error
+	// AddCompletion counts a completion on day, adds its karma and returns
+	// the day's count including it
+	AddCompletion(userID string, day time.Time, karma int) (int, error)
+	AddKarma(userID string, karma int) error
+	// RemoveCompletion takes back a completion counted on day with its karma
+	RemoveCompletion(userID string, day time.Time, karma int) error
+	// CountCompleted sums the completions from day from through day to
+	CountCompleted(userID string, from, to time.Time) (int, error)
+	// GetDays lists the days from from through to with completions, in
+	// ascending order
+	GetDays(userID string, from, to time.Time) ([]*DailyStats, error)
+}
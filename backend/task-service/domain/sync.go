@@ -0,0 +1,52 @@
+package domain
+
+import "time"
+
+// TaskTombstone tells a syncing client that a task is gone: it is in the
+// trash, or was purged from it
+type TaskTombstone struct {
+	TaskID    string
+	DeletedAt time.Time
+}
+
+// SyncChanges are the tasks a user's client has to apply to catch up. Token
+// marks the state they bring the client to, for the next sync.
+type SyncChanges struct {
+	Token   string
+	Tasks   []*Task
+	Deleted []*TaskTombstone
+}
+
+// SyncRepository reads the changes of a user's tasks
+type SyncRepository interface {
+	// GetChanges returns the live tasks written since the token, along with
+	// tombstones of the tasks deleted since, from one consistent snapshot.
+	// An empty token returns every live task and no tombstones. Changes
+	// that were in flight when the token was taken are returned again, so a
+	// client can see a task more than once but never misses one.
+	GetChanges(userID, token string) (*SyncChanges, error)
+}
+
+// SyncCommand records a sync command that was applied, under the UUID the
+// client gave it. TaskID is the task a command with a TempID created.
+type SyncCommand struct {
+	UserID    string
+	UUID      string
+	TempID    *string
+	TaskID    *string
+	CreatedAt time.Time
+}
+
+// SyncCommandRepository remembers applied sync commands, so a client can
+// resend its queue after a lost response without applying a command twice
+type SyncCommandRepository interface {
+	// Claim records a command that is about to be applied in the current
+	// transaction and reports whether it was new. It waits for a concurrent
+	// transaction claiming the same command to finish.
+	Claim(command *SyncCommand) (bool, error)
+	Get(userID, uuid string) (*SyncCommand, error)
+	SetTaskID(userID, uuid, taskID string) error
+	// ResolveTempID returns the task created for a temp ID, most recent
+	// first, and sql.ErrNoRows when there is none
+	ResolveTempID(userID, tempID string) (string, error)
+}
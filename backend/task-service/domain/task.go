@@ -1,24 +1,242 @@
 package domain
 
-import "time"
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	TaskStatusPending   = "pending"
+	TaskStatusCompleted = "completed"
+)
 
 type Task struct {
-	ID          string
-	Title       string
-	Description string
-	Status      string
-	Priority    int
-	UserID      string
-	ProjectID   *string
-	DueDate     *time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID               string
+	Title            string
+	Description      string
+	Status           string // see Complete and Reopen for the allowed transitions
+	Priority         int
+	UserID           string
+	ProjectID        *string
+	ParentID         *string
+	DueDate          *time.Time // see Due for how all-day tasks are stored
+	DueAllDay        bool       // due on a whole day rather than at a time
+	DueTimezone      string     // IANA zone of a timed due date, empty for UTC
+	RecurrenceRule   string     // RFC 5545 RRULE value, empty for one-off tasks
+	EstimatedMinutes *int       // expected effort in minutes, nil when not estimated
+	CompletedAt      *time.Time // when the task was completed, nil while it is pending
+	Position         string     // manual order rank within the project or inbox, see RankBetween
+	Labels           []string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	Version          int        // for optimistic locking, bumped by every write
+	DeletedAt        *time.Time // set while the task is in the trash
+}
+
+// ErrTaskVersionConflict is returned by TaskRepository.Update when the task
+// was changed since it was read
+var ErrTaskVersionConflict = errors.New("task was modified by another request")
+
+// ErrInvalidTaskStatus is returned for a status other than pending and
+// completed
+var ErrInvalidTaskStatus = errors.New("status must be pending or completed")
+
+// PriorityForLevel converts a Todoist priority level (p1 is the highest)
+// to the stored priority, where 4 is the highest
+func PriorityForLevel(level int) int {
+	return 5 - level
+}
+
+// IsCompleted reports whether the task has been completed
+func (t *Task) IsCompleted() bool {
+	return t.Status == TaskStatusCompleted
+}
+
+// Complete moves a pending task to completed at now; a completed task is
+// left as it is
+func (t *Task) Complete(now time.Time) {
+	if t.IsCompleted() {
+		return
+	}
+	completedAt := now.UTC()
+	t.Status = TaskStatusCompleted
+	t.CompletedAt = &completedAt
 }
 
+// Reopen moves a completed task back to pending
+func (t *Task) Reopen() {
+	t.Status = TaskStatusPending
+	t.CompletedAt = nil
+}
+
+// SetStatus moves the task to status, which must be pending or completed,
+// through Complete or Reopen
+func (t *Task) SetStatus(status string, now time.Time) error {
+	switch status {
+	case TaskStatusCompleted:
+		t.Complete(now)
+	case TaskStatusPending:
+		t.Reopen()
+	default:
+		return ErrInvalidTaskStatus
+	}
+	return nil
+}
+
+// IsDeleted reports whether the task is in the trash
+func (t *Task) IsDeleted() bool {
+	return t.DeletedAt != nil
+}
+
+// IsSubtask reports whether the task has a parent task
+func (t *Task) IsSubtask() bool {
+	return t.ParentID != nil
+}
+
+// HasAnyLabel reports whether the task carries at least one of the labels,
+// compared case-insensitively
+func (t *Task) HasAnyLabel(names []string) bool {
+	for _, label := range t.Labels {
+		for _, name := range names {
+			if strings.EqualFold(label, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsRecurring reports whether the task repeats
+func (t *Task) IsRecurring() bool {
+	return t.RecurrenceRule != ""
+}
+
+// SetRecurrence parses a recurrence rule and stores it in canonical RRULE
+// form. A task without a due date is scheduled, all day, for the first
+// occurrence on or after today.
+func (t *Task) SetRecurrence(text string, now time.Time) error {
+	if text == "" {
+		t.RecurrenceRule = ""
+		return nil
+	}
+
+	rule, err := ParseRecurrence(text)
+	if err != nil {
+		return err
+	}
+
+	if t.DueDate == nil {
+		today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		first, ok := rule.FirstOccurrence(today)
+		if !ok {
+			return fmt.Errorf("%w: rule has no upcoming occurrences", ErrInvalidRecurrence)
+		}
+		t.SetDue(&Due{At: first, AllDay: true})
+	}
+
+	t.RecurrenceRule = rule.String()
+	return nil
+}
+
+// AdvanceRecurrence rolls a recurring task's due date forward to the next
+// occurrence after now, keeping its wall-clock time in DueLocation across
+// daylight saving changes. It returns false when the rule has no
+// occurrences left, in which case the task should be completed instead.
+func (t *Task) AdvanceRecurrence(now time.Time) (bool, error) {
+	rule, err := ParseRecurrence(t.RecurrenceRule)
+	if err != nil {
+		return false, err
+	}
+
+	// COUNT includes the occurrence being completed
+	if rule.Count == 1 {
+		return false, nil
+	}
+
+	from := now
+	if t.DueDate != nil {
+		from = *t.LocalDue()
+	}
+	after := now
+	if from.After(after) {
+		after = from
+	}
+
+	next, ok := rule.Next(from, after)
+	if !ok {
+		return false, nil
+	}
+
+	if rule.Count > 1 {
+		rule.Count--
+		t.RecurrenceRule = rule.String()
+	}
+	next = next.UTC()
+	t.DueDate = &next
+	return true, nil
+}
+
+// TaskRepository persists tasks and their hierarchy. Update only succeeds
+// while the stored version still equals task.Version, and increments it.
+// Hierarchy rules:
+//   - a subtask always lives in the same project as its parent
+//   - completing a task completes all of its open descendants
+//   - reopening a subtask reopens any completed ancestors
+//   - deleting a task moves its whole subtree to the trash
+//   - restoring a task restores the descendants trashed with it and any
+//     trashed ancestors
+//
+// Only GetDeleted, GetTrash and Restore see trashed tasks; every other
+// query skips them.
 type TaskRepository interface {
 	Create(task *Task) error
 	GetByID(id string) (*Task, error)
 	GetByUserID(userID string) ([]*Task, error)
+	// Find lists a page of the user's tasks matching query, in its sort order
+	Find(userID string, query TaskQuery) ([]*Task, error)
+	// Search ranks the tasks matching every search term, as a word prefix,
+	// in their title, description or comments
+	Search(userID string, terms []string, query TaskQuery) ([]*TaskSearchResult, error)
 	Update(task *Task) error
+	// Delete moves a task and its subtree to the trash
 	Delete(id string) error
+
+	// GetDeleted returns a task that is in the trash
+	GetDeleted(id string) (*Task, error)
+	// GetTrash lists the user's trashed tasks that were deleted on their own
+	// rather than with a parent, most recently deleted first
+	GetTrash(userID string) ([]*Task, error)
+	// Restore takes a task out of the trash and returns the restored tasks
+	// as they were in the trash. Restored subtasks join the project of their
+	// tree, which may have moved in the meantime.
+	Restore(id string) ([]*Task, error)
+	// PurgeDeleted permanently removes the tasks trashed before the given
+	// time and returns how many were removed
+	PurgeDeleted(before time.Time) (int64, error)
+
+	// GetSubtree returns the task followed by all of its descendants,
+	// ordered by depth
+	GetSubtree(rootID string) ([]*Task, error)
+	// GetAncestors returns the parent chain of a task, nearest first
+	GetAncestors(id string) ([]*Task, error)
+	// MoveSubtree re-parents a task and moves its whole subtree to projectID
+	MoveSubtree(rootID string, parentID, projectID *string) error
+	// SetSubtreeStatus sets the status of a task and all of its descendants,
+	// stamping or clearing their completion time
+	SetSubtreeStatus(rootID, status string) error
+
+	// Positions are ranked per user within a project, or within the inbox
+	// for a nil projectID. Trashed tasks keep their place.
+	//
+	// LastPosition returns the highest rank in the scope, empty when it has
+	// no tasks
+	LastPosition(userID string, projectID *string) (string, error)
+	// PositionBefore and PositionAfter return the nearest rank strictly
+	// below or above position in the scope, empty when there is none
+	PositionBefore(userID string, projectID *string, position string) (string, error)
+	PositionAfter(userID string, projectID *string, position string) (string, error)
+	// SetPosition moves a task to a new rank
+	SetPosition(id, position string) error
 }
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+// TaskCompletion records a single completion of a task. Recurring tasks get
+// one entry per completed occurrence.
+type TaskCompletion struct {
+	ID          string
+	TaskID      string
+	UserID      string
+	DueDate     *time.Time
+	CompletedAt time.Time
+	// Karma is what the completion added to the user's karma, goal bonuses
+	// included, and StatsDay the day it was counted on; both are taken back
+	// when the task is reopened. StatsDay is nil for completions recorded
+	// before stats were kept with them.
+	Karma    int
+	StatsDay *time.Time
+}
+
+type TaskCompletionRepository interface {
+	Create(completion *TaskCompletion) error
+	GetByTaskID(taskID string) ([]*TaskCompletion, error)
+	// GetLatest returns the task's most recent completion, sql.ErrNoRows
+	// when it has none
+	GetLatest(taskID string) (*TaskCompletion, error)
+	Delete(id string) error
+}
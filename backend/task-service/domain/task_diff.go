@@ -0,0 +1,148 @@
+package domain
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// FieldChange is the value of a task field before and after a change
+type FieldChange struct {
+	Old interface{} `json:"old"`
+	New interface{} `json:"new"`
+}
+
+// Clone returns a copy of the task that shares no mutable state with it
+func (t *Task) Clone() *Task {
+	clone := *t
+	clone.ProjectID = cloneString(t.ProjectID)
+	clone.ParentID = cloneString(t.ParentID)
+	if t.DueDate != nil {
+		dueDate := *t.DueDate
+		clone.DueDate = &dueDate
+	}
+	if t.EstimatedMinutes != nil {
+		estimate := *t.EstimatedMinutes
+		clone.EstimatedMinutes = &estimate
+	}
+	if t.CompletedAt != nil {
+		completedAt := *t.CompletedAt
+		clone.CompletedAt = &completedAt
+	}
+	if t.DeletedAt != nil {
+		deletedAt := *t.DeletedAt
+		clone.DeletedAt = &deletedAt
+	}
+	if t.Labels != nil {
+		clone.Labels = append([]string{}, t.Labels...)
+	}
+	return &clone
+}
+
+// DiffTasks lists the user-visible fields that differ between two versions
+// of a task, keyed by their API name. Pointers are dereferenced so that nil
+// stands for a cleared value. A nil before stands for a task that did not
+// exist yet, so every field that is set shows up as a change.
+func DiffTasks(before, after *Task) map[string]FieldChange {
+	oldFields, newFields := taskFields(before), taskFields(after)
+	changes := make(map[string]FieldChange)
+	compare := func(field string) {
+		if _, seen := changes[field]; !seen && !reflect.DeepEqual(oldFields[field], newFields[field]) {
+			changes[field] = FieldChange{Old: oldFields[field], New: newFields[field]}
+		}
+	}
+	for field := range oldFields {
+		compare(field)
+	}
+	for field := range newFields {
+		compare(field)
+	}
+	return changes
+}
+
+// taskFields maps the compared fields of a task to their values, leaving
+// out unset ones; a nil task has no fields
+func taskFields(t *Task) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if t == nil {
+		return fields
+	}
+	set := func(field string, value interface{}) {
+		if value != nil {
+			fields[field] = value
+		}
+	}
+
+	set("title", t.Title)
+	set("description", t.Description)
+	set("status", t.Status)
+	set("priority", t.Priority)
+	set("project_id", derefString(t.ProjectID))
+	set("parent_id", derefString(t.ParentID))
+	set("due_date", dueValue(t))
+	if t.DueTimezone != "" {
+		set("due_timezone", t.DueTimezone)
+	}
+	set("recurrence", t.RecurrenceRule)
+	set("estimated_minutes", derefInt(t.EstimatedMinutes))
+	set("completed_at", derefTime(t.CompletedAt))
+	set("labels", sortedLabels(t.Labels))
+	set("position", t.Position)
+	set("deleted_at", derefTime(t.DeletedAt))
+
+	return fields
+}
+
+// dueValue renders the due date the way the API does, as a date for all-day
+// tasks and a datetime in its zone otherwise
+func dueValue(t *Task) interface{} {
+	due := t.LocalDue()
+	switch {
+	case due == nil:
+		return nil
+	case t.DueAllDay:
+		return due.Format(DueDateLayout)
+	default:
+		return due.Format(time.RFC3339)
+	}
+}
+
+func cloneString(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	clone := *s
+	return &clone
+}
+
+func derefString(s *string) interface{} {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func derefInt(i *int) interface{} {
+	if i == nil {
+		return nil
+	}
+	return *i
+}
+
+func derefTime(t *time.Time) interface{} {
+	if t == nil {
+		return nil
+	}
+	return t.UTC()
+}
+
+// sortedLabels orders label names like the repository does, so that label
+// order never shows up as a change
+func sortedLabels(labels []string) []string {
+	sorted := append([]string{}, labels...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return strings.ToLower(sorted[i]) < strings.ToLower(sorted[j])
+	})
+	return sorted
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// TaskImport records a row of an import file that was imported, under a key
+// derived from the row, so importing the file again skips it. TaskID is the
+// task created for the row.
+type TaskImport struct {
+	UserID    string
+	Key       string
+	TaskID    *string
+	CreatedAt time.Time
+}
+
+// TaskImportRepository remembers imported rows
+type TaskImportRepository interface {
+	// Claim records a row that is about to be imported in the current
+	// transaction and reports whether it was new. It waits for a concurrent
+	// transaction claiming the same row to finish.
+	Claim(record *TaskImport) (bool, error)
+	// Get returns the record of an imported row, and sql.ErrNoRows when
+	// the row was not imported
+	Get(userID, key string) (*TaskImport, error)
+	SetTaskID(userID, key, taskID string) error
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/todoist/backend/task-service/domain/filter"
+)
+
+// TaskQuery narrows a listing of a user's tasks; zero fields are ignored
+type TaskQuery struct {
+	Status    string
+	Priority  *int
+	ProjectID *string
+	Labels    []string // matches tasks with any of the labels
+	// Blocked keeps the tasks that wait on an open blocker when true and
+	// the ones that do not when false
+	Blocked *bool
+	// CompletedFrom and CompletedTo keep the tasks completed in
+	// [CompletedFrom, CompletedTo)
+	CompletedFrom *time.Time
+	CompletedTo   *time.Time
+	Filter        filter.Expr
+	// Now is the reference time for relative dates such as "today"
+	Now time.Time
+
+	Sort       TaskSort
+	Descending bool
+	// After continues a listing after the cursor's task; it must have been
+	// produced with the same Sort and Descending
+	After *TaskCursor
+	// Limit caps the number of tasks returned; zero means no limit
+	Limit int
+}
+
+// ProjectLookup resolves project names, which are owned by project-service
+type ProjectLookup interface {
+	FindProjectIDsByName(ctx context.Context, userID, name string) ([]string, error)
+}
+
+// TaskSort is the key a task listing is ordered by. Ties are broken by task
+// ID so that every ordering is total and can be paged with a cursor.
+type TaskSort string
+
+const (
+	TaskSortDueDate   TaskSort = "due_date"
+	TaskSortPriority  TaskSort = "priority"
+	TaskSortCreatedAt TaskSort = "created_at"
+	TaskSortPosition  TaskSort = "position"
+	// TaskSortCompletedAt orders by completion time; pending tasks sort last
+	TaskSortCompletedAt TaskSort = "completed_at"
+)
+
+// ParseTaskSort validates a sort key; an empty string selects created_at
+func ParseTaskSort(s string) (TaskSort, error) {
+	switch sort := TaskSort(s); sort {
+	case "":
+		return TaskSortCreatedAt, nil
+	case TaskSortDueDate, TaskSortPriority, TaskSortCreatedAt, TaskSortPosition, TaskSortCompletedAt:
+		return sort, nil
+	}
+	return "", ErrInvalidTaskSort
+}
+
+// DefaultDescending reports the natural direction of the sort key: newest,
+// most recently completed and most important first, earliest due date and
+// manual order first
+func (s TaskSort) DefaultDescending() bool {
+	return s == TaskSortCreatedAt || s == TaskSortCompletedAt || s == TaskSortPriority
+}
+
+var ErrInvalidTaskSort = errors.New("sort must be one of due_date, priority, created_at, position, completed_at")
+
+// TaskCursor marks the last task of a page. It records the sort the page
+// was produced with and the sort key values of that task, so the next page
+// can continue strictly after it.
+type TaskCursor struct {
+	Sort        TaskSort
+	Descending  bool
+	ID          string
+	Priority    int
+	DueDate     *time.Time
+	CreatedAt   time.Time
+	Position    string
+	CompletedAt *time.Time
+}
+
+// CursorAfter returns the cursor continuing a listing after task
+func CursorAfter(task *Task, sort TaskSort, descending bool) *TaskCursor {
+	return &TaskCursor{
+		Sort:        sort,
+		Descending:  descending,
+		ID:          task.ID,
+		Priority:    task.Priority,
+		DueDate:     task.DueDate,
+		CreatedAt:   task.CreatedAt,
+		Position:    task.Position,
+		CompletedAt: task.CompletedAt,
+	}
+}
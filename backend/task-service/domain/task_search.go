@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"strings"
+	"unicode"
+)
+
+// TaskSearchResult is a task matched by a full-text search. The highlights
+// are HTML-escaped excerpts with matching words wrapped in <mark> tags;
+// CommentHighlight is set when the best match was in one of the task's
+// comments.
+type TaskSearchResult struct {
+	Task                 *Task
+	Rank                 float64
+	TitleHighlight       string
+	DescriptionHighlight string
+	CommentHighlight     string
+}
+
+// SearchTerms splits a search query into lower-cased words, dropping
+// punctuation and operators so user input never reaches the query parser
+func SearchTerms(q string) []string {
+	return strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
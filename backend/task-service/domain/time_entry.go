@@ -0,0 +1,199 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// MaxEstimatedMinutes bounds a task's estimate
+const MaxEstimatedMinutes = 1000 * 60
+
+// ErrInvalidEstimate is returned for an estimate outside 0 to
+// MaxEstimatedMinutes
+var ErrInvalidEstimate = fmt.Errorf("estimated_minutes must be between 0 and %d", MaxEstimatedMinutes)
+
+// SetEstimate sets the expected effort in minutes; zero removes it
+func (t *Task) SetEstimate(minutes int) error {
+	if minutes < 0 || minutes > MaxEstimatedMinutes {
+		return ErrInvalidEstimate
+	}
+	if minutes == 0 {
+		t.EstimatedMinutes = nil
+		return nil
+	}
+	t.EstimatedMinutes = &minutes
+	return nil
+}
+
+// TimeEntry is a span of time the user worked on a task. An entry without
+// StoppedAt is a running timer; a user has at most one.
+type TimeEntry struct {
+	ID        string
+	TaskID    string
+	UserID    string
+	StartedAt time.Time
+	StoppedAt *time.Time
+	Note      string
+	CreatedAt time.Time
+}
+
+// ErrTimerRunning is returned when starting a timer while another one of
+// the user's timers is running
+var ErrTimerRunning = errors.New("a timer is already running")
+
+// IsRunning reports whether the entry is a running timer
+func (e *TimeEntry) IsRunning() bool {
+	return e.StoppedAt == nil
+}
+
+// End is when the entry stopped, or now for a running timer
+func (e *TimeEntry) End(now time.Time) time.Time {
+	if e.StoppedAt != nil {
+		return *e.StoppedAt
+	}
+	return now
+}
+
+// Duration is the tracked time so far
+func (e *TimeEntry) Duration(now time.Time) time.Duration {
+	return e.End(now).Sub(e.StartedAt)
+}
+
+// within clips the entry to [from, to), reporting false when nothing is
+// left
+func (e *TimeEntry) within(from, to, now time.Time) (time.Time, time.Time, bool) {
+	start, end := e.StartedAt, e.End(now)
+	if start.Before(from) {
+		start = from
+	}
+	if end.After(to) {
+		end = to
+	}
+	return start, end, end.After(start)
+}
+
+// Time report groupings
+const (
+	TimeReportByProject = "project"
+	TimeReportByLabel   = "label"
+	TimeReportByDay     = "day"
+)
+
+// TrackedTime is a time entry with the fields of its task that reports
+// group by
+type TrackedTime struct {
+	Entry     *TimeEntry
+	ProjectID *string
+	Labels    []string
+}
+
+// TimeTotal is the tracked time of one report group. Key is the project ID,
+// label name or date of the group, nil for time on tasks without a project
+// or label.
+type TimeTotal struct {
+	Key      *string
+	Duration time.Duration
+	Entries  int
+}
+
+// SummarizeTrackedTime totals the time tracked within [from, to) by
+// groupBy, one of the TimeReportBy constants. Running timers count up to
+// now. Days are those of from's location, and an entry that spans midnight
+// is split between its days. Time on a task with several labels counts
+// toward each of them. Days come in order, other groups by decreasing time.
+func SummarizeTrackedTime(tracked []*TrackedTime, groupBy string, from, to, now time.Time) []*TimeTotal {
+	totals := make(map[string]*TimeTotal)
+	add := func(key *string, d time.Duration) {
+		mapKey := "\x00"
+		if key != nil {
+			mapKey = *key
+		}
+		total, ok := totals[mapKey]
+		if !ok {
+			total = &TimeTotal{Key: key}
+			totals[mapKey] = total
+		}
+		total.Duration += d
+		total.Entries++
+	}
+
+	for _, t := range tracked {
+		start, end, ok := t.Entry.within(from, to, now)
+		if !ok {
+			continue
+		}
+
+		switch groupBy {
+		case TimeReportByDay:
+			loc := from.Location()
+			for start.Before(end) {
+				local := start.In(loc)
+				next := time.Date(local.Year(), local.Month(), local.Day()+1, 0, 0, 0, 0, loc)
+				if next.After(end) {
+					next = end
+				}
+				day := local.Format(DueDateLayout)
+				add(&day, next.Sub(start))
+				start = next
+			}
+		case TimeReportByLabel:
+			if len(t.Labels) == 0 {
+				add(nil, end.Sub(start))
+			}
+			for i := range t.Labels {
+				add(&t.Labels[i], end.Sub(start))
+			}
+		default:
+			add(t.ProjectID, end.Sub(start))
+		}
+	}
+
+	result := make([]*TimeTotal, 0, len(totals))
+	for _, total := range totals {
+		result = append(result, total)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		a, b := result[i], result[j]
+		if groupBy != TimeReportByDay && a.Duration != b.Duration {
+			return a.Duration > b.Duration
+		}
+		if a.Key == nil || b.Key == nil {
+			return b.Key == nil && a.Key != nil
+		}
+		return *a.Key < *b.Key
+	})
+	return result
+}
+
+// TotalTrackedTime is the time tracked within [from, to), where running
+// timers count up to now
+func TotalTrackedTime(tracked []*TrackedTime, from, to, now time.Time) time.Duration {
+	var total time.Duration
+	for _, t := range tracked {
+		if start, end, ok := t.Entry.within(from, to, now); ok {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// TimeEntryRepository persists time entries
+type TimeEntryRepository interface {
+	// Create stores an entry, returning ErrTimerRunning for a running entry
+	// while the user already has one
+	Create(entry *TimeEntry) error
+	GetByID(id string) (*TimeEntry, error)
+	GetByTaskID(taskID string) ([]*TimeEntry, error)
+	// GetRunning returns the user's running timer, sql.ErrNoRows when there
+	// is none
+	GetRunning(userID string) (*TimeEntry, error)
+	// Stop stops a running timer, returning sql.ErrNoRows when it is not
+	// running anymore
+	Stop(id string, stoppedAt time.Time) error
+	Delete(id string) error
+	// GetTracked lists the user's entries overlapping [from, to), including
+	// those on trashed tasks
+	GetTracked(userID string, from, to time.Time) ([]*TrackedTime, error)
+}
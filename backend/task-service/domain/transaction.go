@@ -0,0 +1,24 @@
+package domain
+
+import "context"
+
+// Repositories are the repositories bound to one transaction
+type Repositories struct {
+	Tasks        TaskRepository
+	Completions  TaskCompletionRepository
+	Labels       LabelRepository
+	Comments     CommentRepository
+	Outbox       OutboxRepository
+	Activity     ActivityRepository
+	Reminders    ReminderRepository
+	Dependencies DependencyRepository
+	SyncCommands SyncCommandRepository
+	Stats        StatsRepository
+	Imports      TaskImportRepository
+}
+
+// Transactor runs fn with repositories whose writes commit together. When
+// fn returns an error every write is rolled back.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(repos Repositories) error) error
+}
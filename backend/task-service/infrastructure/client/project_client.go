@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// ProjectClient looks up projects in project-service
+type ProjectClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewProjectClient creates a client for the project-service at baseURL
+func NewProjectClient(baseURL string) *ProjectClient {
+	return &ProjectClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+var _ domain.ProjectLookup = (*ProjectClient)(nil)
+
+type projectResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// FindProjectIDsByName returns the IDs of the user's projects named name,
+// compared case-insensitively. A project ID is accepted in place of a name.
+func (c *ProjectClient) FindProjectIDsByName(ctx context.Context, userID, name string) ([]string, error) {
+	if uuidPattern.MatchString(name) {
+		return []string{strings.ToLower(name)}, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/projects", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-User-ID", userID)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach project service: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("project service returned status %d", resp.StatusCode)
+	}
+
+	var projects []projectResponse
+	if err := json.NewDecoder(resp.Body).Decode(&projects); err != nil {
+		return nil, fmt.Errorf("failed to decode projects: %w", err)
+	}
+
+	var ids []string
+	for _, project := range projects {
+		if strings.EqualFold(project.Name, name) {
+			ids = append(ids, project.ID)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,152 @@
+package messaging
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const (
+	outboxBatchSize = 100
+	// outboxLease must comfortably exceed publishTimeout: a batch can take
+	// far longer than a lease, so the relay renews it once half has passed
+	outboxLease      = time.Minute
+	outboxMaxBackoff = 5 * time.Minute
+	publishTimeout   = 10 * time.Second
+	// outboxMaxAttempts gives up on a message after about an hour of
+	// failures, so that one the broker keeps rejecting is not retried forever
+	outboxMaxAttempts = 20
+)
+
+// OutboxRelay publishes the events written to the transactional outbox.
+// Every replica may run one: relays claim disjoint batches, and a failed
+// publish is retried with exponential backoff until outboxMaxAttempts.
+type OutboxRelay struct {
+	outbox    domain.OutboxRepository
+	publisher *RabbitMQPublisher
+	logger    *logger.Logger
+	interval  time.Duration
+}
+
+// NewOutboxRelay creates a relay polling the outbox every interval
+func NewOutboxRelay(outbox domain.OutboxRepository, publisher *RabbitMQPublisher, log *logger.Logger, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		outbox:    outbox,
+		publisher: publisher,
+		logger:    log,
+		interval:  interval,
+	}
+}
+
+// Run relays messages until ctx is cancelled
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		// Drain the backlog before waiting for the next tick
+		for {
+			relayed, err := r.relayBatch(ctx)
+			if err != nil {
+				r.logger.WithError(err).Error("failed to relay outbox")
+			}
+			if err != nil || relayed < outboxBatchSize {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// relayBatch publishes one batch of due messages and returns its size
+func (r *OutboxRelay) relayBatch(ctx context.Context) (int, error) {
+	messages, err := r.outbox.ClaimDue(outboxBatchSize, outboxLease)
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+
+	renewedAt := time.Now()
+	for i, message := range messages {
+		if ctx.Err() != nil {
+			// Unpublished messages become due again when their lease expires
+			return len(messages), nil
+		}
+
+		// Keep the rest of the batch from being claimed and published again
+		// by another relay while a slow broker holds this one up
+		if time.Since(renewedAt) > outboxLease/2 {
+			if err := r.outbox.ExtendLease(messageIDs(messages[i:]), outboxLease); err != nil {
+				return len(messages), err
+			}
+			renewedAt = time.Now()
+		}
+
+		publishCtx, cancel := context.WithTimeout(ctx, publishTimeout)
+		err := r.publisher.PublishMessage(publishCtx, message.RoutingKey, message.ID, message.Payload)
+		cancel()
+
+		if err != nil {
+			if err := r.markFailed(message, err); err != nil {
+				return len(messages), err
+			}
+			continue
+		}
+
+		if err := r.outbox.MarkPublished(message.ID); err != nil {
+			return len(messages), err
+		}
+	}
+
+	return len(messages), nil
+}
+
+// markFailed schedules another attempt at a message that failed to
+// publish, or gives up on it after outboxMaxAttempts
+func (r *OutboxRelay) markFailed(message *domain.OutboxMessage, publishErr error) error {
+	attempts := message.Attempts + 1
+	log := r.logger.WithError(publishErr).WithFields(map[string]interface{}{
+		"message_id":  message.ID,
+		"routing_key": message.RoutingKey,
+		"attempts":    attempts,
+	})
+
+	if attempts >= outboxMaxAttempts {
+		log.Error("giving up on outbox message")
+		return r.outbox.MarkDead(message.ID, publishErr.Error())
+	}
+
+	delay := retryDelay(message.Attempts)
+	log.WithFields(map[string]interface{}{"retry_in": delay.String()}).Warn("failed to publish outbox message")
+	return r.outbox.MarkFailed(message.ID, publishErr.Error(), delay)
+}
+
+func messageIDs(messages []*domain.OutboxMessage) []string {
+	ids := make([]string, len(messages))
+	for i, message := range messages {
+		ids[i] = message.ID
+	}
+	return ids
+}
+
+// retryDelay backs off exponentially from one second up to outboxMaxBackoff
+func retryDelay(attempts int) time.Duration {
+	delay := time.Second
+	for i := 0; i < attempts && delay < outboxMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > outboxMaxBackoff {
+		delay = outboxMaxBackoff
+	}
+	return delay
+}
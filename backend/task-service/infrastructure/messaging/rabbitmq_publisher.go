@@ -0,0 +1,205 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/rabbitmq/amqp091-go"
+	"github.com/todoist/backend/pkg/events"
+)
+
+const (
+	reconnectDelay    = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// RabbitMQPublisher implements event publishing using RabbitMQ. When the
+// connection or channel closes, such as on a broker restart, it reconnects
+// in the background; publishes fail until it has.
+type RabbitMQPublisher struct {
+	url          string
+	exchangeName string
+	done         chan struct{}
+
+	mu      sync.RWMutex
+	conn    *amqp091.Connection
+	channel *amqp091.Channel
+}
+
+// NewRabbitMQPublisher creates a new RabbitMQ event publisher
+func NewRabbitMQPublisher(url, exchangeName string) (*RabbitMQPublisher, error) {
+	p := &RabbitMQPublisher{
+		url:          url,
+		exchangeName: exchangeName,
+		done:         make(chan struct{}),
+	}
+	if err := p.connect(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// connect opens a connection and a confirming channel and watches them
+func (p *RabbitMQPublisher) connect() error {
+	conn, err := amqp091.Dial(p.url)
+	if err != nil {
+		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	// Declare topic exchange
+	err = channel.ExchangeDeclare(
+		p.exchangeName, // name
+		"topic",        // type
+		true,           // durable
+		false,          // auto-deleted
+		false,          // internal
+		false,          // no-wait
+		nil,            // arguments
+	)
+	if err != nil {
+		channel.Close()
+		conn.Close()
+		return fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	// Have the broker confirm every publish, so a failed one can be retried
+	if err := channel.Confirm(false); err != nil {
+		channel.Close()
+		conn.Close()
+		return fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	connClosed := conn.NotifyClose(make(chan *amqp091.Error, 1))
+	channelClosed := channel.NotifyClose(make(chan *amqp091.Error, 1))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	select {
+	case <-p.done:
+		// Closed while reconnecting
+		channel.Close()
+		conn.Close()
+		return nil
+	default:
+	}
+	p.conn = conn
+	p.channel = channel
+
+	go p.reconnectOnClose(conn, connClosed, channelClosed)
+	return nil
+}
+
+// reconnectOnClose waits until the connection or its channel closes, then
+// reconnects, backing off while the broker is unreachable
+func (p *RabbitMQPublisher) reconnectOnClose(conn *amqp091.Connection, connClosed, channelClosed chan *amqp091.Error) {
+	select {
+	case <-p.done:
+		return
+	case <-connClosed:
+	case <-channelClosed:
+		// The broker closes a channel on errors; start over on a new
+		// connection
+		conn.Close()
+	}
+
+	delay := reconnectDelay
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-time.After(delay):
+		}
+		if err := p.connect(); err == nil {
+			return
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// Publish publishes an event to RabbitMQ
+func (p *RabbitMQPublisher) Publish(ctx context.Context, event interface{}) error {
+	routingKey := p.getRoutingKey(event)
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	return p.PublishMessage(ctx, routingKey, "", body)
+}
+
+// PublishMessage publishes an already serialized event and waits until the
+// broker confirms it
+func (p *RabbitMQPublisher) PublishMessage(ctx context.Context, routingKey, messageID string, body []byte) error {
+	p.mu.RLock()
+	channel := p.channel
+	p.mu.RUnlock()
+
+	confirmation, err := channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		p.exchangeName, // exchange
+		routingKey,     // routing key
+		false,          // mandatory
+		false,          // immediate
+		amqp091.Publishing{
+			ContentType:  "application/json",
+			MessageId:    messageID,
+			Body:         body,
+			DeliveryMode: amqp091.Persistent,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to confirm event: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("event was rejected by the broker")
+	}
+
+	return nil
+}
+
+// Close closes the RabbitMQ connection
+func (p *RabbitMQPublisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	close(p.done)
+
+	// The channel is gone already if the connection dropped
+	p.channel.Close()
+	return p.conn.Close()
+}
+
+func (p *RabbitMQPublisher) getRoutingKey(event interface{}) string {
+	switch e := event.(type) {
+	case events.TaskCreated:
+		return e.EventType
+	case events.TaskUpdated:
+		return e.EventType
+	case events.TaskCompleted:
+		return e.EventType
+	case events.TaskDeleted:
+		return e.EventType
+	case events.TaskRestored:
+		return e.EventType
+	case events.TaskReminderDue:
+		return e.EventType
+	case events.CommentAdded:
+		return e.EventType
+	default:
+		return "unknown"
+	}
+}
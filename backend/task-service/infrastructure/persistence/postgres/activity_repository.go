@@ -0,0 +1,71 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"strconv"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type activityRepository struct {
+	db dbtx
+}
+
+func NewActivityRepository(db *sql.DB) domain.ActivityRepository {
+	return &activityRepository{db: db}
+}
+
+func (r *activityRepository) Add(activity *domain.Activity) error {
+	changes, err := json.Marshal(activity.Changes)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO task_activity (id, task_id, user_id, action, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err = r.db.Exec(query, activity.ID, activity.TaskID, activity.UserID, activity.Action, changes, activity.CreatedAt)
+	return err
+}
+
+func (r *activityRepository) Find(userID string, query domain.ActivityQuery) ([]*domain.Activity, error) {
+	args := []interface{}{userID}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	sqlQuery := `SELECT id, task_id, user_id, action, changes, created_at FROM task_activity WHERE user_id = $1`
+	if query.TaskID != nil {
+		sqlQuery += ` AND task_id = ` + arg(*query.TaskID)
+	}
+	if query.After != nil {
+		sqlQuery += ` AND (created_at, id) < (` + arg(query.After.CreatedAt) + `, ` + arg(query.After.ID) + `)`
+	}
+	sqlQuery += ` ORDER BY created_at DESC, id DESC`
+	if query.Limit > 0 {
+		sqlQuery += ` LIMIT ` + arg(query.Limit)
+	}
+
+	rows, err := r.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var activities []*domain.Activity
+	for rows.Next() {
+		activity := &domain.Activity{}
+		var changes []byte
+		if err := rows.Scan(&activity.ID, &activity.TaskID, &activity.UserID, &activity.Action, &changes, &activity.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &activity.Changes); err != nil {
+			return nil, err
+		}
+		activities = append(activities, activity)
+	}
+	return activities, rows.Err()
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const attachmentColumns = `id, task_id, comment_id, user_id, file_name, content_type, size, checksum, storage_key, created_at`
+
+type attachmentRepository struct {
+	db dbtx
+}
+
+func NewAttachmentRepository(db *sql.DB) domain.AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+func scanAttachment(row rowScanner) (*domain.Attachment, error) {
+	attachment := &domain.Attachment{}
+	err := row.Scan(
+		&attachment.ID, &attachment.TaskID, &attachment.CommentID, &attachment.UserID, &attachment.FileName,
+		&attachment.ContentType, &attachment.Size, &attachment.Checksum, &attachment.StorageKey, &attachment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+func (r *attachmentRepository) queryAttachments(query string, args ...interface{}) ([]*domain.Attachment, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []*domain.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, rows.Err()
+}
+
+func (r *attachmentRepository) Create(attachment *domain.Attachment) error {
+	query := `
+		INSERT INTO attachments (id, task_id, comment_id, user_id, file_name, content_type, size, checksum, storage_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.db.Exec(query, attachment.ID, attachment.TaskID, attachment.CommentID, attachment.UserID, attachment.FileName,
+		attachment.ContentType, attachment.Size, attachment.Checksum, attachment.StorageKey, attachment.CreatedAt)
+	return err
+}
+
+func (r *attachmentRepository) GetByID(id string) (*domain.Attachment, error) {
+	query := `SELECT ` + attachmentColumns + ` FROM attachments WHERE id = $1`
+	return scanAttachment(r.db.QueryRow(query, id))
+}
+
+func (r *attachmentRepository) GetByTaskID(taskID string) ([]*domain.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments a
+		WHERE task_id = $1
+			AND (comment_id IS NULL OR EXISTS (SELECT 1 FROM comments c WHERE c.id = a.comment_id))
+		ORDER BY created_at, id
+	`
+	return r.queryAttachments(query, taskID)
+}
+
+func (r *attachmentRepository) Delete(id string) error {
+	_, err := r.db.Exec(`DELETE FROM attachments WHERE id = $1`, id)
+	return err
+}
+
+func (r *attachmentRepository) GetOrphaned(limit int) ([]*domain.Attachment, error) {
+	query := `
+		SELECT ` + attachmentColumns + `
+		FROM attachments a
+		WHERE NOT EXISTS (SELECT 1 FROM tasks t WHERE t.id = a.task_id)
+			OR (comment_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM comments c WHERE c.id = a.comment_id))
+		ORDER BY created_at
+		LIMIT $1
+	`
+	return r.queryAttachments(query, limit)
+}
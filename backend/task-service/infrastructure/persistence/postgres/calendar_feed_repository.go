@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type calendarFeedRepository struct {
+	db dbtx
+}
+
+func NewCalendarFeedRepository(db *sql.DB) domain.CalendarFeedRepository {
+	return &calendarFeedRepository{db: db}
+}
+
+func (r *calendarFeedRepository) Save(feed *domain.CalendarFeed) error {
+	query := `
+		INSERT INTO calendar_feeds (user_id, token_hash, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE SET token_hash = EXCLUDED.token_hash, created_at = EXCLUDED.created_at
+	`
+	_, err := r.db.Exec(query, feed.UserID, feed.TokenHash, feed.CreatedAt)
+	return err
+}
+
+func (r *calendarFeedRepository) GetByTokenHash(tokenHash string) (*domain.CalendarFeed, error) {
+	query := `SELECT user_id, token_hash, created_at FROM calendar_feeds WHERE token_hash = $1`
+	feed := &domain.CalendarFeed{}
+	err := r.db.QueryRow(query, tokenHash).Scan(&feed.UserID, &feed.TokenHash, &feed.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+func (r *calendarFeedRepository) Delete(userID string) (bool, error) {
+	result, err := r.db.Exec(`DELETE FROM calendar_feeds WHERE user_id = $1`, userID)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type commentRepository struct {
+	db dbtx
+}
+
+func NewCommentRepository(db *sql.DB) domain.CommentRepository {
+	return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(comment *domain.Comment) error {
+	query := `
+		INSERT INTO comments (id, task_id, user_id, content, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.Exec(query, comment.ID, comment.TaskID, comment.UserID, comment.Content,
+		comment.CreatedAt, comment.UpdatedAt)
+	return err
+}
+
+func (r *commentRepository) GetByID(id string) (*domain.Comment, error) {
+	query := `
+		SELECT id, task_id, user_id, content, created_at, updated_at
+		FROM comments WHERE id = $1
+	`
+	comment := &domain.Comment{}
+	err := r.db.QueryRow(query, id).Scan(
+		&comment.ID, &comment.TaskID, &comment.UserID, &comment.Content,
+		&comment.CreatedAt, &comment.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (r *commentRepository) GetByTaskID(taskID string) ([]*domain.Comment, error) {
+	query := `
+		SELECT id, task_id, user_id, content, created_at, updated_at
+		FROM comments WHERE task_id = $1 ORDER BY created_at ASC
+	`
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*domain.Comment
+	for rows.Next() {
+		comment := &domain.Comment{}
+		err := rows.Scan(
+			&comment.ID, &comment.TaskID, &comment.UserID, &comment.Content,
+			&comment.CreatedAt, &comment.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+func (r *commentRepository) Update(comment *domain.Comment) error {
+	query := `UPDATE comments SET content = $1, updated_at = $2 WHERE id = $3`
+	comment.UpdatedAt = time.Now()
+	_, err := r.db.Exec(query, comment.Content, comment.UpdatedAt, comment.ID)
+	return err
+}
+
+func (r *commentRepository) Delete(id string) error {
+	query := `DELETE FROM comments WHERE id = $1`
+	_, err := r.db.Exec(query, id)
+	return err
+}
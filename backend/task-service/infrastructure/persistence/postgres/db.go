@@ -0,0 +1,31 @@
+package postgres
+
+import "database/sql"
+
+// dbtx is implemented by both *sql.DB and *sql.Tx, so a repository works
+// standalone as well as bound to a transaction by the transactor
+type dbtx interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+// inTx runs fn in a transaction of its own, or in the caller's transaction
+// when db already is one
+func inTx(db dbtx, fn func(tx dbtx) error) error {
+	sqlDB, ok := db.(*sql.DB)
+	if !ok {
+		return fn(db)
+	}
+
+	tx, err := sqlDB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
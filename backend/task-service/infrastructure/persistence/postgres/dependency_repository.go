@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/lib/pq"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type dependencyRepository struct {
+	db dbtx
+}
+
+func NewDependencyRepository(db *sql.DB) domain.DependencyRepository {
+	return &dependencyRepository{db: db}
+}
+
+func (r *dependencyRepository) queryDependencies(query string, args ...interface{}) ([]*domain.TaskDependency, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dependencies []*domain.TaskDependency
+	for rows.Next() {
+		dependency := &domain.TaskDependency{}
+		if err := rows.Scan(&dependency.TaskID, &dependency.BlockedByID, &dependency.UserID, &dependency.CreatedAt); err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, dependency)
+	}
+	return dependencies, rows.Err()
+}
+
+func (r *dependencyRepository) queryIDs(query string, args ...interface{}) ([]string, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *dependencyRepository) Add(dependency *domain.TaskDependency) error {
+	query := `
+		INSERT INTO task_dependencies (task_id, blocked_by_id, user_id, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.db.Exec(query, dependency.TaskID, dependency.BlockedByID, dependency.UserID, dependency.CreatedAt)
+	return err
+}
+
+func (r *dependencyRepository) Get(taskID, blockedByID string) (*domain.TaskDependency, error) {
+	query := `
+		SELECT task_id, blocked_by_id, user_id, created_at
+		FROM task_dependencies WHERE task_id = $1 AND blocked_by_id = $2
+	`
+	dependency := &domain.TaskDependency{}
+	err := r.db.QueryRow(query, taskID, blockedByID).Scan(
+		&dependency.TaskID, &dependency.BlockedByID, &dependency.UserID, &dependency.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return dependency, nil
+}
+
+func (r *dependencyRepository) Remove(taskID, blockedByID string) error {
+	_, err := r.db.Exec(`DELETE FROM task_dependencies WHERE task_id = $1 AND blocked_by_id = $2`, taskID, blockedByID)
+	return err
+}
+
+func (r *dependencyRepository) GetByUserID(userID string) ([]*domain.TaskDependency, error) {
+	query := `SELECT task_id, blocked_by_id, user_id, created_at FROM task_dependencies WHERE user_id = $1`
+	return r.queryDependencies(query, userID)
+}
+
+func (r *dependencyRepository) GetBlockerIDs(taskID string) ([]string, error) {
+	query := `SELECT blocked_by_id FROM task_dependencies WHERE task_id = $1 ORDER BY created_at, blocked_by_id`
+	return r.queryIDs(query, taskID)
+}
+
+func (r *dependencyRepository) GetBlockedIDs(taskID string) ([]string, error) {
+	query := `SELECT task_id FROM task_dependencies WHERE blocked_by_id = $1 ORDER BY created_at, task_id`
+	return r.queryIDs(query, taskID)
+}
+
+func (r *dependencyRepository) OpenBlockers(taskIDs []string) ([]*domain.TaskDependency, error) {
+	query := `
+		SELECT d.task_id, d.blocked_by_id, d.user_id, d.created_at
+		FROM task_dependencies d
+		JOIN tasks b ON b.id = d.blocked_by_id
+		WHERE d.task_id = ANY($1::uuid[]) AND b.status = $2 AND b.deleted_at IS NULL
+		ORDER BY d.created_at
+	`
+	return r.queryDependencies(query, pq.Array(taskIDs), domain.TaskStatusPending)
+}
+
+func (r *dependencyRepository) LockUser(userID string) error {
+	_, err := r.db.Exec(`SELECT pg_advisory_xact_lock(hashtext('task_dependencies:' || $1))`, userID)
+	return err
+}
@@ -7,13 +7,232 @@ CREATE TABLE IF NOT EXISTS tasks (
     priority INTEGER NOT NULL DEFAULT 1,
     user_id UUID NOT NULL,
     project_id UUID,
+    parent_id UUID REFERENCES tasks(id) ON DELETE CASCADE,
     due_date TIMESTAMP,
+    due_all_day BOOLEAN NOT NULL DEFAULT FALSE,
+    due_timezone TEXT NOT NULL DEFAULT '',
+    recurrence_rule TEXT NOT NULL DEFAULT '',
+    estimated_minutes INTEGER CHECK (estimated_minutes > 0),
+    completed_at TIMESTAMP,
+    position TEXT COLLATE "C" NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    version INTEGER NOT NULL DEFAULT 1,
+    deleted_at TIMESTAMP,
+    sync_xid xid8 NOT NULL DEFAULT pg_current_xact_id(),
+    search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED
+);
+
+-- Stamp every task write with its transaction for incremental sync
+CREATE OR REPLACE FUNCTION set_task_sync_xid() RETURNS trigger AS $$
+BEGIN
+    NEW.sync_xid := pg_current_xact_id();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+DROP TRIGGER IF EXISTS tasks_sync_xid ON tasks;
+CREATE TRIGGER tasks_sync_xid BEFORE UPDATE ON tasks FOR EACH ROW EXECUTE FUNCTION set_task_sync_xid();
+
+-- Create task completion history table
+CREATE TABLE IF NOT EXISTS task_completions (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    due_date TIMESTAMP,
+    completed_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    karma INTEGER NOT NULL DEFAULT 0,
+    stats_day DATE
+);
+
+-- Create labels and the task/label link table
+CREATE TABLE IF NOT EXISTS labels (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    name VARCHAR(60) NOT NULL,
+    color VARCHAR(50) NOT NULL DEFAULT 'charcoal',
     created_at TIMESTAMP NOT NULL DEFAULT NOW(),
     updated_at TIMESTAMP NOT NULL DEFAULT NOW()
 );
 
+CREATE TABLE IF NOT EXISTS task_labels (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    label_id UUID NOT NULL REFERENCES labels(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, label_id)
+);
+
+-- Create comments table
+CREATE TABLE IF NOT EXISTS comments (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    search_vector tsvector GENERATED ALWAYS AS (to_tsvector('english', content)) STORED
+);
+
+-- Create transactional outbox for domain events
+CREATE TABLE IF NOT EXISTS outbox (
+    id UUID PRIMARY KEY,
+    routing_key VARCHAR(255) NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    dead_at TIMESTAMP
+);
+
+-- Create append-only task activity history; entries outlive their tasks
+CREATE TABLE IF NOT EXISTS task_activity (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create task reminders; fire_at is the next time a reminder fires
+CREATE TABLE IF NOT EXISTS task_reminders (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    remind_at TIMESTAMP,
+    minutes_before INTEGER,
+    fire_at TIMESTAMP,
+    fired_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK ((remind_at IS NULL) <> (minutes_before IS NULL))
+);
+
+-- Create blocked-by links; task_id waits on blocked_by_id
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocked_by_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, blocked_by_id),
+    CHECK (task_id <> blocked_by_id)
+);
+
+-- Create tracked time; stopped_at is null while the timer runs
+CREATE TABLE IF NOT EXISTS time_entries (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL,
+    started_at TIMESTAMP NOT NULL,
+    stopped_at TIMESTAMP,
+    note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    CHECK (stopped_at IS NULL OR stopped_at >= started_at)
+);
+
+-- Create attachment metadata; the bytes live in the blob store. Rows
+-- outlive their task or comment until their blob has been deleted.
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY,
+    task_id UUID NOT NULL,
+    comment_id UUID,
+    user_id UUID NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum CHAR(64) NOT NULL,
+    storage_key TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Create tombstones of purged tasks for clients syncing from before the
+-- purge
+CREATE TABLE IF NOT EXISTS task_tombstones (
+    task_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    deleted_at TIMESTAMP NOT NULL,
+    sync_xid xid8 NOT NULL DEFAULT pg_current_xact_id()
+);
+
+-- Create the record of applied sync commands, which makes retried
+-- commands no-ops and maps their temp IDs to the tasks they created
+CREATE TABLE IF NOT EXISTS sync_commands (
+    user_id UUID NOT NULL,
+    uuid UUID NOT NULL,
+    temp_id VARCHAR(64),
+    task_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, uuid)
+);
+
+-- Create productivity stats, kept up to date by every completion: goals
+-- and running totals per user, and completions per day in the user's
+-- time zone
+CREATE TABLE IF NOT EXISTS user_stats (
+    user_id UUID PRIMARY KEY,
+    karma INTEGER NOT NULL DEFAULT 0,
+    completed_count INTEGER NOT NULL DEFAULT 0,
+    daily_goal INTEGER NOT NULL DEFAULT 5,
+    weekly_goal INTEGER NOT NULL DEFAULT 25,
+    timezone TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS user_daily_stats (
+    user_id UUID NOT NULL,
+    day DATE NOT NULL,
+    completed INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, day)
+);
+
+-- Create the record of imported rows, which makes importing a file
+-- again skip the rows it already imported
+CREATE TABLE IF NOT EXISTS task_imports (
+    user_id UUID NOT NULL,
+    import_key CHAR(64) NOT NULL,
+    task_id UUID,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, import_key)
+);
+
+-- Create calendar feed tokens, kept as hashes, one per user
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    user_id UUID PRIMARY KEY,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
 -- Create indexes for better performance
 CREATE INDEX IF NOT EXISTS idx_tasks_user_id ON tasks(user_id);
 CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks(status);
 CREATE INDEX IF NOT EXISTS idx_tasks_priority ON tasks(priority);
 CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks(project_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks(parent_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_created_at ON tasks(user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_due_date ON tasks(user_id, due_date, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_priority ON tasks(user_id, priority, id);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_project_position ON tasks(user_id, project_id, position);
+CREATE INDEX IF NOT EXISTS idx_tasks_user_completed_at ON tasks(user_id, completed_at, id) WHERE completed_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_user_sync_xid ON tasks(user_id, sync_xid);
+CREATE INDEX IF NOT EXISTS idx_task_completions_task_id ON task_completions(task_id);
+CREATE INDEX IF NOT EXISTS idx_task_completions_user_id ON task_completions(user_id, completed_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_labels_user_name ON labels(user_id, lower(name));
+CREATE INDEX IF NOT EXISTS idx_task_labels_label_id ON task_labels(label_id);
+CREATE INDEX IF NOT EXISTS idx_comments_task_id ON comments(task_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_search ON tasks USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_comments_search ON comments USING GIN(search_vector);
+CREATE INDEX IF NOT EXISTS idx_outbox_next_attempt_at ON outbox(next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_task_activity_user ON task_activity(user_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_task_activity_task ON task_activity(task_id, created_at DESC, id DESC);
+CREATE INDEX IF NOT EXISTS idx_task_reminders_task_id ON task_reminders(task_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_task_reminders_fire_at ON task_reminders(fire_at) WHERE fire_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_blocked_by ON task_dependencies(blocked_by_id);
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_user_id ON task_dependencies(user_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries(task_id, started_at);
+CREATE INDEX IF NOT EXISTS idx_time_entries_user_started_at ON time_entries(user_id, started_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries(user_id) WHERE stopped_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_attachments_task_id ON attachments(task_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_task_tombstones_user_sync_xid ON task_tombstones(user_id, sync_xid);
+CREATE INDEX IF NOT EXISTS idx_sync_commands_temp_id ON sync_commands(user_id, temp_id, created_at) WHERE temp_id IS NOT NULL;
@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type labelRepository struct {
+	db dbtx
+}
+
+func NewLabelRepository(db *sql.DB) domain.LabelRepository {
+	return &labelRepository{db: db}
+}
+
+func scanLabels(rows *sql.Rows) ([]*domain.Label, error) {
+	defer rows.Close()
+
+	var labels []*domain.Label
+	for rows.Next() {
+		label := &domain.Label{}
+		err := rows.Scan(&label.ID, &label.UserID, &label.Name, &label.Color, &label.CreatedAt, &label.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, label)
+	}
+	return labels, rows.Err()
+}
+
+func (r *labelRepository) Create(label *domain.Label) error {
+	query := `
+		INSERT INTO labels (id, user_id, name, color, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`
+	_, err := r.db.Exec(query, label.ID, label.UserID, label.Name, label.Color, label.CreatedAt, label.UpdatedAt)
+	return err
+}
+
+func (r *labelRepository) GetByID(id string) (*domain.Label, error) {
+	query := `
+		SELECT id, user_id, name, color, created_at, updated_at
+		FROM labels WHERE id = $1
+	`
+	label := &domain.Label{}
+	err := r.db.QueryRow(query, id).Scan(
+		&label.ID, &label.UserID, &label.Name, &label.Color, &label.CreatedAt, &label.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return label, nil
+}
+
+func (r *labelRepository) GetByUserID(userID string) ([]*domain.Label, error) {
+	query := `
+		SELECT id, user_id, name, color, created_at, updated_at
+		FROM labels WHERE user_id = $1 ORDER BY lower(name)
+	`
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, err
+	}
+	return scanLabels(rows)
+}
+
+func (r *labelRepository) GetByNames(userID string, names []string) ([]*domain.Label, error) {
+	lowered := make([]string, len(names))
+	for i, name := range names {
+		lowered[i] = strings.ToLower(name)
+	}
+
+	query := `
+		SELECT id, user_id, name, color, created_at, updated_at
+		FROM labels WHERE user_id = $1 AND lower(name) = ANY($2)
+	`
+	rows, err := r.db.Query(query, userID, pq.Array(lowered))
+	if err != nil {
+		return nil, err
+	}
+	return scanLabels(rows)
+}
+
+func (r *labelRepository) Update(label *domain.Label) error {
+	label.UpdatedAt = time.Now()
+	query := `UPDATE labels SET name = $1, color = $2, updated_at = $3 WHERE id = $4`
+	_, err := r.db.Exec(query, label.Name, label.Color, label.UpdatedAt, label.ID)
+	return err
+}
+
+func (r *labelRepository) Delete(id string) error {
+	_, err := r.db.Exec(`DELETE FROM labels WHERE id = $1`, id)
+	return err
+}
+
+func (r *labelRepository) GetTaskIDs(labelID string) ([]string, error) {
+	query := `
+		SELECT t.id FROM tasks t
+		JOIN task_labels tl ON tl.task_id = t.id
+		WHERE tl.label_id = $1 AND t.deleted_at IS NULL
+		ORDER BY t.id
+	`
+	rows, err := r.db.Query(query, labelID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func (r *labelRepository) TouchTasks(labelID string, at time.Time) error {
+	query := `
+		UPDATE tasks SET updated_at = $2, version = version + 1
+		WHERE id IN (SELECT task_id FROM task_labels WHERE label_id = $1) AND deleted_at IS NULL
+	`
+	_, err := r.db.Exec(query, labelID, at)
+	return err
+}
+
+func (r *labelRepository) SetTaskLabels(taskID string, labelIDs []string) error {
+	return inTx(r.db, func(tx dbtx) error {
+		if _, err := tx.Exec(`DELETE FROM task_labels WHERE task_id = $1`, taskID); err != nil {
+			return err
+		}
+		if len(labelIDs) == 0 {
+			return nil
+		}
+		query := `
+			INSERT INTO task_labels (task_id, label_id)
+			SELECT $1, unnest($2::uuid[])
+			ON CONFLICT DO NOTHING
+		`
+		_, err := tx.Exec(query, taskID, pq.Array(labelIDs))
+		return err
+	})
+}
@@ -0,0 +1,88 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type outboxRepository struct {
+	db dbtx
+}
+
+func NewOutboxRepository(db *sql.DB) domain.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func (r *outboxRepository) Add(message *domain.OutboxMessage) error {
+	query := `
+		INSERT INTO outbox (id, routing_key, payload, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	_, err := r.db.Exec(query, message.ID, message.RoutingKey, message.Payload, message.CreatedAt)
+	return err
+}
+
+func (r *outboxRepository) ClaimDue(limit int, lease time.Duration) ([]*domain.OutboxMessage, error) {
+	// SKIP LOCKED lets concurrent relays claim disjoint batches; pushing
+	// next_attempt_at forward is the lease
+	query := `
+		UPDATE outbox SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE next_attempt_at <= NOW() AND dead_at IS NULL
+			ORDER BY created_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, routing_key, payload, attempts, last_error, created_at
+	`
+	rows, err := r.db.Query(query, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []*domain.OutboxMessage
+	for rows.Next() {
+		message := &domain.OutboxMessage{}
+		err := rows.Scan(&message.ID, &message.RoutingKey, &message.Payload, &message.Attempts, &message.LastError, &message.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, rows.Err()
+}
+
+func (r *outboxRepository) ExtendLease(ids []string, lease time.Duration) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	query := `UPDATE outbox SET next_attempt_at = NOW() + $2 * INTERVAL '1 millisecond' WHERE id = ANY($1::uuid[])`
+	_, err := r.db.Exec(query, pq.Array(ids), lease.Milliseconds())
+	return err
+}
+
+func (r *outboxRepository) MarkPublished(id string) error {
+	_, err := r.db.Exec(`DELETE FROM outbox WHERE id = $1`, id)
+	return err
+}
+
+func (r *outboxRepository) MarkFailed(id string, reason string, retryAfter time.Duration) error {
+	query := `
+		UPDATE outbox
+		SET attempts = attempts + 1, last_error = $2, next_attempt_at = NOW() + $3 * INTERVAL '1 millisecond'
+		WHERE id = $1
+	`
+	_, err := r.db.Exec(query, id, reason, retryAfter.Milliseconds())
+	return err
+}
+
+func (r *outboxRepository) MarkDead(id string, reason string) error {
+	query := `UPDATE outbox SET attempts = attempts + 1, last_error = $2, dead_at = NOW() WHERE id = $1`
+	_, err := r.db.Exec(query, id, reason)
+	return err
+}
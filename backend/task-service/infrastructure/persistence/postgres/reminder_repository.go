@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const reminderColumns = `id, task_id, user_id, remind_at, minutes_before, fire_at, fired_at, created_at`
+
+type reminderRepository struct {
+	db dbtx
+}
+
+func NewReminderRepository(db *sql.DB) domain.ReminderRepository {
+	return &reminderRepository{db: db}
+}
+
+func scanReminder(row rowScanner) (*domain.Reminder, error) {
+	reminder := &domain.Reminder{}
+	var minutesBefore sql.NullInt64
+	err := row.Scan(
+		&reminder.ID, &reminder.TaskID, &reminder.UserID, &reminder.RemindAt, &minutesBefore,
+		&reminder.FireAt, &reminder.FiredAt, &reminder.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if minutesBefore.Valid {
+		minutes := int(minutesBefore.Int64)
+		reminder.MinutesBefore = &minutes
+	}
+	return reminder, nil
+}
+
+func (r *reminderRepository) queryReminders(query string, args ...interface{}) ([]*domain.Reminder, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reminders []*domain.Reminder
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	return reminders, rows.Err()
+}
+
+func (r *reminderRepository) Create(reminder *domain.Reminder) error {
+	query := `
+		INSERT INTO task_reminders (id, task_id, user_id, remind_at, minutes_before, fire_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	_, err := r.db.Exec(query, reminder.ID, reminder.TaskID, reminder.UserID, reminder.RemindAt,
+		reminder.MinutesBefore, reminder.FireAt, reminder.CreatedAt)
+	return err
+}
+
+func (r *reminderRepository) GetByID(id string) (*domain.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM task_reminders WHERE id = $1`
+	return scanReminder(r.db.QueryRow(query, id))
+}
+
+func (r *reminderRepository) GetByTaskID(taskID string) ([]*domain.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM task_reminders WHERE task_id = $1 ORDER BY created_at, id`
+	return r.queryReminders(query, taskID)
+}
+
+func (r *reminderRepository) Delete(id string) error {
+	_, err := r.db.Exec(`DELETE FROM task_reminders WHERE id = $1`, id)
+	return err
+}
+
+func (r *reminderRepository) Reschedule(reminders []*domain.Reminder) error {
+	for _, reminder := range reminders {
+		_, err := r.db.Exec(`UPDATE task_reminders SET fire_at = $2 WHERE id = $1`, reminder.ID, reminder.FireAt)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *reminderRepository) UnscheduleSubtree(rootID string) error {
+	query := subtreeCTE + `
+		UPDATE task_reminders SET fire_at = NULL
+		WHERE task_id IN (SELECT id FROM subtree) AND fire_at IS NOT NULL
+	`
+	_, err := r.db.Exec(query, rootID)
+	return err
+}
+
+func (r *reminderRepository) ClaimDue(now time.Time, limit int) ([]*domain.Reminder, error) {
+	// The row locks last until the claiming transaction ends, and SKIP
+	// LOCKED keeps other schedulers off the claimed rows meanwhile
+	query := `
+		SELECT r.id, r.task_id, r.user_id, r.remind_at, r.minutes_before, r.fire_at, r.fired_at, r.created_at
+		FROM task_reminders r
+		JOIN tasks t ON t.id = r.task_id
+		WHERE r.fire_at <= $1 AND t.deleted_at IS NULL AND t.status = $2
+		ORDER BY r.fire_at
+		LIMIT $3
+		FOR UPDATE OF r SKIP LOCKED
+	`
+	return r.queryReminders(query, now.UTC(), domain.TaskStatusPending, limit)
+}
+
+func (r *reminderRepository) MarkFired(id string, firedAt time.Time) error {
+	_, err := r.db.Exec(`UPDATE task_reminders SET fire_at = NULL, fired_at = $2 WHERE id = $1`, id, firedAt.UTC())
+	return err
+}
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const userStatsColumns = `user_id, karma, completed_count, daily_goal, weekly_goal, timezone, updated_at`
+
+type statsRepository struct {
+	db dbtx
+}
+
+func NewStatsRepository(db *sql.DB) domain.StatsRepository {
+	return &statsRepository{db: db}
+}
+
+func scanUserStats(row rowScanner) (*domain.UserStats, error) {
+	stats := &domain.UserStats{}
+	err := row.Scan(
+		&stats.UserID, &stats.Karma, &stats.CompletedCount, &stats.DailyGoal, &stats.WeeklyGoal,
+		&stats.Timezone, &stats.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
+
+func (r *statsRepository) Get(userID string) (*domain.UserStats, error) {
+	query := `SELECT ` + userStatsColumns + ` FROM user_stats WHERE user_id = $1`
+	return scanUserStats(r.db.QueryRow(query, userID))
+}
+
+func (r *statsRepository) Lock(defaults *domain.UserStats) (*domain.UserStats, error) {
+	query := `
+		INSERT INTO user_stats (user_id, daily_goal, weekly_goal, timezone, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id) DO NOTHING
+	`
+	_, err := r.db.Exec(query, defaults.UserID, defaults.DailyGoal, defaults.WeeklyGoal,
+		defaults.Timezone, defaults.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `SELECT ` + userStatsColumns + ` FROM user_stats WHERE user_id = $1 FOR UPDATE`
+	return scanUserStats(r.db.QueryRow(query, defaults.UserID))
+}
+
+func (r *statsRepository) SaveGoals(stats *domain.UserStats) error {
+	query := `
+		UPDATE user_stats SET daily_goal = $2, weekly_goal = $3, timezone = $4, updated_at = $5
+		WHERE user_id = $1
+	`
+	_, err := r.db.Exec(query, stats.UserID, stats.DailyGoal, stats.WeeklyGoal, stats.Timezone, stats.UpdatedAt)
+	return err
+}
+
+func (r *statsRepository) AddCompletion(userID string, day time.Time, karma int) (int, error) {
+	query := `
+		UPDATE user_stats SET karma = karma + $2, completed_count = completed_count + 1, updated_at = NOW()
+		WHERE user_id = $1
+	`
+	if _, err := r.db.Exec(query, userID, karma); err != nil {
+		return 0, err
+	}
+
+	query = `
+		INSERT INTO user_daily_stats (user_id, day, completed) VALUES ($1, $2::date, 1)
+		ON CONFLICT (user_id, day) DO UPDATE SET completed = user_daily_stats.completed + 1
+		RETURNING completed
+	`
+	var completed int
+	err := r.db.QueryRow(query, userID, day.Format(domain.DueDateLayout)).Scan(&completed)
+	return completed, err
+}
+
+func (r *statsRepository) AddKarma(userID string, karma int) error {
+	_, err := r.db.Exec(`UPDATE user_stats SET karma = karma + $2 WHERE user_id = $1`, userID, karma)
+	return err
+}
+
+func (r *statsRepository) RemoveCompletion(userID string, day time.Time, karma int) error {
+	query := `
+		UPDATE user_stats
+		SET karma = karma - $2, completed_count = GREATEST(completed_count - 1, 0), updated_at = NOW()
+		WHERE user_id = $1
+	`
+	if _, err := r.db.Exec(query, userID, karma); err != nil {
+		return err
+	}
+
+	query = `
+		UPDATE user_daily_stats SET completed = GREATEST(completed - 1, 0)
+		WHERE user_id = $1 AND day = $2::date
+	`
+	_, err := r.db.Exec(query, userID, day.Format(domain.DueDateLayout))
+	return err
+}
+
+func (r *statsRepository) CountCompleted(userID string, from, to time.Time) (int, error) {
+	query := `
+		SELECT COALESCE(SUM(completed), 0) FROM user_daily_stats
+		WHERE user_id = $1 AND day BETWEEN $2::date AND $3::date
+	`
+	var completed int
+	err := r.db.QueryRow(query, userID, from.Format(domain.DueDateLayout), to.Format(domain.DueDateLayout)).Scan(&completed)
+	return completed, err
+}
+
+func (r *statsRepository) GetDays(userID string, from, to time.Time) ([]*domain.DailyStats, error) {
+	query := `
+		SELECT day, completed FROM user_daily_stats
+		WHERE user_id = $1 AND day BETWEEN $2::date AND $3::date AND completed > 0
+		ORDER BY day
+	`
+	rows, err := r.db.Query(query, userID, from.Format(domain.DueDateLayout), to.Format(domain.DueDateLayout))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var days []*domain.DailyStats
+	for rows.Next() {
+		day := &domain.DailyStats{}
+		if err := rows.Scan(&day.Day, &day.Completed); err != nil {
+			return nil, err
+		}
+		day.Day = day.Day.UTC()
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import "github.com/todoist/backend/task-service/domain"
+
+// syncCommandRepository is only used bound to the transaction applying a
+// command
+type syncCommandRepository struct {
+	db dbtx
+}
+
+func (r *syncCommandRepository) Claim(command *domain.SyncCommand) (bool, error) {
+	// A conflicting insert waits until the transaction holding the row ends;
+	// it only goes through when that transaction rolled back
+	query := `
+		INSERT INTO sync_commands (user_id, uuid, temp_id, task_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, uuid) DO NOTHING
+	`
+	result, err := r.db.Exec(query, command.UserID, command.UUID, command.TempID, command.TaskID, command.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+func (r *syncCommandRepository) Get(userID, uuid string) (*domain.SyncCommand, error) {
+	query := `SELECT user_id, uuid, temp_id, task_id, created_at FROM sync_commands WHERE user_id = $1 AND uuid = $2`
+	command := &domain.SyncCommand{}
+	err := r.db.QueryRow(query, userID, uuid).Scan(
+		&command.UserID, &command.UUID, &command.TempID, &command.TaskID, &command.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return command, nil
+}
+
+func (r *syncCommandRepository) SetTaskID(userID, uuid, taskID string) error {
+	_, err := r.db.Exec(`UPDATE sync_commands SET task_id = $3 WHERE user_id = $1 AND uuid = $2`, userID, uuid, taskID)
+	return err
+}
+
+func (r *syncCommandRepository) ResolveTempID(userID, tempID string) (string, error) {
+	query := `
+		SELECT task_id FROM sync_commands
+		WHERE user_id = $1 AND temp_id = $2 AND task_id IS NOT NULL
+		ORDER BY created_at DESC
+		LIMIT 1
+	`
+	var taskID string
+	err := r.db.QueryRow(query, userID, tempID).Scan(&taskID)
+	return taskID, err
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type syncRepository struct {
+	db *sql.DB
+}
+
+func NewSyncRepository(db *sql.DB) domain.SyncRepository {
+	return &syncRepository{db: db}
+}
+
+// GetChanges reads in a repeatable read transaction, so the token and the
+// changes come from one snapshot. Every write stamps its rows with its
+// transaction ID in sync_xid; the token is the oldest transaction still in
+// flight for the snapshot, below which every write is visible to it.
+func (r *syncRepository) GetChanges(userID, token string) (*domain.SyncChanges, error) {
+	tx, err := r.db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	changes := &domain.SyncChanges{}
+	if err := tx.QueryRow(`SELECT pg_snapshot_xmin(pg_current_snapshot())::text`).Scan(&changes.Token); err != nil {
+		return nil, err
+	}
+
+	tasks := &taskRepository{db: tx}
+	if token == "" {
+		query := `SELECT ` + taskColumns + ` FROM tasks WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created_at, id`
+		changes.Tasks, err = tasks.queryTasks(query, userID)
+		if err != nil {
+			return nil, err
+		}
+		return changes, tx.Commit()
+	}
+
+	query := `
+		SELECT ` + taskColumns + ` FROM tasks
+		WHERE user_id = $1 AND sync_xid >= $2::xid8 AND deleted_at IS NULL
+		ORDER BY created_at, id
+	`
+	changes.Tasks, err = tasks.queryTasks(query, userID, token)
+	if err != nil {
+		return nil, err
+	}
+
+	query = `
+		SELECT id, deleted_at FROM tasks
+		WHERE user_id = $1 AND sync_xid >= $2::xid8 AND deleted_at IS NOT NULL
+		UNION ALL
+		SELECT task_id, deleted_at FROM task_tombstones
+		WHERE user_id = $1 AND sync_xid >= $2::xid8
+		ORDER BY deleted_at
+	`
+	rows, err := tx.Query(query, userID, token)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		tombstone := &domain.TaskTombstone{}
+		if err := rows.Scan(&tombstone.TaskID, &tombstone.DeletedAt); err != nil {
+			return nil, err
+		}
+		changes.Deleted = append(changes.Deleted, tombstone)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return changes, tx.Commit()
+}
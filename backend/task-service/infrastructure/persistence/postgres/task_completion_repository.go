@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const taskCompletionColumns = `id, task_id, user_id, due_date, completed_at, karma, stats_day`
+
+type taskCompletionRepository struct {
+	db dbtx
+}
+
+func NewTaskCompletionRepository(db *sql.DB) domain.TaskCompletionRepository {
+	return &taskCompletionRepository{db: db}
+}
+
+func scanTaskCompletion(row rowScanner) (*domain.TaskCompletion, error) {
+	completion := &domain.TaskCompletion{}
+	err := row.Scan(
+		&completion.ID, &completion.TaskID, &completion.UserID,
+		&completion.DueDate, &completion.CompletedAt, &completion.Karma, &completion.StatsDay,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if completion.StatsDay != nil {
+		day := completion.StatsDay.UTC()
+		completion.StatsDay = &day
+	}
+	return completion, nil
+}
+
+func (r *taskCompletionRepository) Create(completion *domain.TaskCompletion) error {
+	query := `
+		INSERT INTO task_completions (id, task_id, user_id, due_date, completed_at, karma, stats_day)
+		VALUES ($1, $2, $3, $4, $5, $6, $7::date)
+	`
+	var statsDay *string
+	if completion.StatsDay != nil {
+		day := completion.StatsDay.Format(domain.DueDateLayout)
+		statsDay = &day
+	}
+	_, err := r.db.Exec(query, completion.ID, completion.TaskID, completion.UserID,
+		completion.DueDate, completion.CompletedAt, completion.Karma, statsDay)
+	return err
+}
+
+func (r *taskCompletionRepository) GetByTaskID(taskID string) ([]*domain.TaskCompletion, error) {
+	query := `
+		SELECT ` + taskCompletionColumns + `
+		FROM task_completions WHERE task_id = $1 ORDER BY completed_at DESC
+	`
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var completions []*domain.TaskCompletion
+	for rows.Next() {
+		completion, err := scanTaskCompletion(rows)
+		if err != nil {
+			return nil, err
+		}
+		completions = append(completions, completion)
+	}
+	return completions, rows.Err()
+}
+
+func (r *taskCompletionRepository) GetLatest(taskID string) (*domain.TaskCompletion, error) {
+	query := `
+		SELECT ` + taskCompletionColumns + `
+		FROM task_completions WHERE task_id = $1 ORDER BY completed_at DESC LIMIT 1
+	`
+	return scanTaskCompletion(r.db.QueryRow(query, taskID))
+}
+
+func (r *taskCompletionRepository) Delete(id string) error {
+	_, err := r.db.Exec(`DELETE FROM task_completions WHERE id = $1`, id)
+	return err
+}
@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type taskImportRepository struct {
+	db dbtx
+}
+
+func NewTaskImportRepository(db *sql.DB) domain.TaskImportRepository {
+	return &taskImportRepository{db: db}
+}
+
+func (r *taskImportRepository) Claim(record *domain.TaskImport) (bool, error) {
+	// As with sync commands, a conflicting insert waits for the transaction
+	// holding the row and only goes through when it rolled back
+	query := `
+		INSERT INTO task_imports (user_id, import_key, task_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, import_key) DO NOTHING
+	`
+	result, err := r.db.Exec(query, record.UserID, record.Key, record.TaskID, record.CreatedAt)
+	if err != nil {
+		return false, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rowsAffected == 1, nil
+}
+
+func (r *taskImportRepository) Get(userID, key string) (*domain.TaskImport, error) {
+	query := `SELECT user_id, import_key, task_id, created_at FROM task_imports WHERE user_id = $1 AND import_key = $2`
+	record := &domain.TaskImport{}
+	err := r.db.QueryRow(query, userID, key).Scan(&record.UserID, &record.Key, &record.TaskID, &record.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (r *taskImportRepository) SetTaskID(userID, key, taskID string) error {
+	_, err := r.db.Exec(`UPDATE task_imports SET task_id = $3 WHERE user_id = $1 AND import_key = $2`, userID, key, taskID)
+	return err
+}
@@ -0,0 +1,290 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/todoist/backend/task-service/domain"
+	"github.com/todoist/backend/task-service/domain/filter"
+)
+
+// whereBuilder accumulates the conditions and positional arguments of a
+// parameterized WHERE clause
+type whereBuilder struct {
+	conditions []string
+	args       []interface{}
+}
+
+func (b *whereBuilder) arg(value interface{}) string {
+	b.args = append(b.args, value)
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+func (b *whereBuilder) add(condition string) {
+	b.conditions = append(b.conditions, condition)
+}
+
+func (b *whereBuilder) sql() string {
+	if len(b.conditions) == 0 {
+		return "TRUE"
+	}
+	return strings.Join(b.conditions, " AND ")
+}
+
+// buildTaskQuery compiles a TaskQuery into a WHERE clause over tasks
+func buildTaskQuery(userID string, query domain.TaskQuery) (*whereBuilder, error) {
+	b := &whereBuilder{}
+	b.add("user_id = " + b.arg(userID))
+	b.add("deleted_at IS NULL")
+
+	if query.Status != "" {
+		b.add("status = " + b.arg(query.Status))
+	}
+	if query.Priority != nil {
+		b.add("priority = " + b.arg(*query.Priority))
+	}
+	if query.ProjectID != nil {
+		b.add("project_id = " + b.arg(*query.ProjectID))
+	}
+	if len(query.Labels) > 0 {
+		lowered := make([]string, len(query.Labels))
+		for i, label := range query.Labels {
+			lowered[i] = strings.ToLower(strings.TrimPrefix(label, "@"))
+		}
+		b.add(`EXISTS (
+			SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE tl.task_id = tasks.id AND lower(l.name) = ANY(` + b.arg(pq.Array(lowered)) + `))`)
+	}
+	if query.Blocked != nil {
+		condition := `EXISTS (
+			SELECT 1 FROM task_dependencies d JOIN tasks b ON b.id = d.blocked_by_id
+			WHERE d.task_id = tasks.id AND b.status = ` + b.arg(domain.TaskStatusPending) + ` AND b.deleted_at IS NULL)`
+		if !*query.Blocked {
+			condition = "NOT " + condition
+		}
+		b.add(condition)
+	}
+	if query.CompletedFrom != nil {
+		b.add("completed_at >= " + b.arg(query.CompletedFrom.UTC()))
+	}
+	if query.CompletedTo != nil {
+		b.add("completed_at < " + b.arg(query.CompletedTo.UTC()))
+	}
+	if query.Filter != nil {
+		now := query.Now
+		if now.IsZero() {
+			now = time.Now()
+		}
+		condition, err := b.compileFilter(query.Filter, now)
+		if err != nil {
+			return nil, err
+		}
+		b.add(condition)
+	}
+
+	return b, nil
+}
+
+// compileFilter translates a filter expression into SQL. Every leaf yields
+// TRUE or FALSE, never NULL, so that negation behaves as users expect.
+// Days are the user's, in now's location.
+func (b *whereBuilder) compileFilter(expr filter.Expr, now time.Time) (string, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	dueBetween := func(from, to time.Time) string {
+		return "(" + b.dueCompare(">=", from, now.Location()) + " AND " + b.dueCompare("<", to, now.Location()) + ")"
+	}
+
+	switch e := expr.(type) {
+	case *filter.And:
+		left, err := b.compileFilter(e.Left, now)
+		if err != nil {
+			return "", err
+		}
+		right, err := b.compileFilter(e.Right, now)
+		if err != nil {
+			return "", err
+		}
+		return "(" + left + " AND " + right + ")", nil
+	case *filter.Or:
+		left, err := b.compileFilter(e.Left, now)
+		if err != nil {
+			return "", err
+		}
+		right, err := b.compileFilter(e.Right, now)
+		if err != nil {
+			return "", err
+		}
+		return "(" + left + " OR " + right + ")", nil
+	case *filter.Not:
+		inner, err := b.compileFilter(e.Expr, now)
+		if err != nil {
+			return "", err
+		}
+		return "(NOT " + inner + ")", nil
+	case *filter.All:
+		return "TRUE", nil
+	case *filter.Priority:
+		return "priority = " + b.arg(domain.PriorityForLevel(e.Level)), nil
+	case *filter.Project:
+		if len(e.IDs) == 0 {
+			return "FALSE", nil
+		}
+		return "COALESCE(project_id = ANY(" + b.arg(pq.Array(e.IDs)) + "::uuid[]), FALSE)", nil
+	case *filter.Label:
+		return `EXISTS (
+			SELECT 1 FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+			WHERE tl.task_id = tasks.id AND lower(l.name) = lower(` + b.arg(e.Name) + `))`, nil
+	case *filter.NoLabels:
+		return "NOT EXISTS (SELECT 1 FROM task_labels tl WHERE tl.task_id = tasks.id)", nil
+	case *filter.Recurring:
+		return "recurrence_rule <> ''", nil
+	case *filter.Subtask:
+		return "parent_id IS NOT NULL", nil
+	case *filter.Due:
+		switch e.Kind {
+		case filter.DueToday:
+			return dueBetween(today, today.AddDate(0, 0, 1)), nil
+		case filter.DueTomorrow:
+			return dueBetween(today.AddDate(0, 0, 1), today.AddDate(0, 0, 2)), nil
+		case filter.DueYesterday:
+			return dueBetween(today.AddDate(0, 0, -1), today), nil
+		case filter.DueOverdue:
+			// All-day tasks are overdue once their day is over, timed ones
+			// once their moment has passed
+			return fmt.Sprintf("(due_date IS NOT NULL AND due_date < CASE WHEN due_all_day THEN %s::timestamp ELSE %s::timestamp END)",
+				b.arg(today), b.arg(now.UTC())), nil
+		case filter.DueNone:
+			return "due_date IS NULL", nil
+		case filter.DueWithinDays:
+			return dueBetween(today, today.AddDate(0, 0, e.Days)), nil
+		case filter.DueBefore:
+			return b.dueCompare("<", e.Date, now.Location()), nil
+		case filter.DueAfter:
+			return b.dueCompare(">=", e.Date.AddDate(0, 0, 1), now.Location()), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported filter node %T", expr)
+}
+
+// dueCompare compares due dates with the start of a day. All-day tasks,
+// stored as their date at midnight UTC, compare with the date itself;
+// timed tasks with the moment the day starts in loc.
+func (b *whereBuilder) dueCompare(op string, day time.Time, loc *time.Location) string {
+	date := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, loc).UTC()
+	return fmt.Sprintf("(due_date IS NOT NULL AND due_date %s CASE WHEN due_all_day THEN %s::timestamp ELSE %s::timestamp END)",
+		op, b.arg(date), b.arg(start))
+}
+
+// sortKey is one column of a listing's ORDER BY
+type sortKey struct {
+	column   string
+	nullable bool
+	value    func(cursor *domain.TaskCursor) interface{}
+}
+
+// taskSortKeys lists the columns each sort orders by, before the id
+// tie-breaker
+var taskSortKeys = map[domain.TaskSort][]sortKey{
+	domain.TaskSortCreatedAt: {
+		{column: "created_at", value: func(c *domain.TaskCursor) interface{} { return c.CreatedAt }},
+	},
+	domain.TaskSortPriority: {
+		{column: "priority", value: func(c *domain.TaskCursor) interface{} { return c.Priority }},
+	},
+	domain.TaskSortDueDate: {
+		{column: "due_date", nullable: true, value: func(c *domain.TaskCursor) interface{} {
+			if c.DueDate == nil {
+				return nil
+			}
+			return *c.DueDate
+		}},
+	},
+	domain.TaskSortPosition: {
+		{column: "position", value: func(c *domain.TaskCursor) interface{} { return c.Position }},
+	},
+	domain.TaskSortCompletedAt: {
+		{column: "completed_at", nullable: true, value: func(c *domain.TaskCursor) interface{} {
+			if c.CompletedAt == nil {
+				return nil
+			}
+			return *c.CompletedAt
+		}},
+	},
+}
+
+// orderBy returns the ORDER BY clause for the query's sort. NULL values
+// sort last in either direction.
+func orderBy(keys []sortKey, descending bool) string {
+	direction := "ASC"
+	if descending {
+		direction = "DESC"
+	}
+
+	var columns []string
+	for _, key := range keys {
+		column := key.column + " " + direction
+		if key.nullable {
+			column += " NULLS LAST"
+		}
+		columns = append(columns, column)
+	}
+	columns = append(columns, "id "+direction)
+	return strings.Join(columns, ", ")
+}
+
+// keysetAfter compiles the condition selecting rows that sort strictly
+// after the cursor, consistent with orderBy
+func (b *whereBuilder) keysetAfter(keys []sortKey, cursor *domain.TaskCursor) string {
+	op := ">"
+	if cursor.Descending {
+		op = "<"
+	}
+
+	if len(keys) == 0 {
+		return "id " + op + " " + b.arg(cursor.ID)
+	}
+
+	key, rest := keys[0], keys[1:]
+	value := key.value(cursor)
+	if value == nil {
+		// Only NULLs follow a NULL, which sort last
+		return "(" + key.column + " IS NULL AND " + b.keysetAfter(rest, cursor) + ")"
+	}
+
+	placeholder := b.arg(value)
+	condition := fmt.Sprintf("%s %s %s OR (%s = %s AND %s)",
+		key.column, op, placeholder, key.column, placeholder, b.keysetAfter(rest, cursor))
+	if key.nullable {
+		condition = key.column + " IS NULL OR " + condition
+	}
+	return "(" + condition + ")"
+}
+
+func (r *taskRepository) Find(userID string, query domain.TaskQuery) ([]*domain.Task, error) {
+	where, err := buildTaskQuery(userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	sort := query.Sort
+	if sort == "" {
+		sort = domain.TaskSortCreatedAt
+	}
+	keys, ok := taskSortKeys[sort]
+	if !ok {
+		return nil, domain.ErrInvalidTaskSort
+	}
+	if query.After != nil {
+		where.add(where.keysetAfter(keys, query.After))
+	}
+
+	sqlQuery := `SELECT ` + taskColumns + ` FROM tasks WHERE ` + where.sql() + ` ORDER BY ` + orderBy(keys, query.Descending)
+	if query.Limit > 0 {
+		sqlQuery += ` LIMIT ` + where.arg(query.Limit)
+	}
+	return r.queryTasks(sqlQuery, where.args...)
+}
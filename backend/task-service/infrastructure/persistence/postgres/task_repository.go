@@ -4,36 +4,39 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/lib/pq"
 	"github.com/todoist/backend/task-service/domain"
 )
 
+const taskColumns = `id, title, description, status, priority, user_id, project_id, parent_id, due_date, due_all_day, due_timezone, recurrence_rule, estimated_minutes, completed_at, position, created_at, updated_at, version, deleted_at`
+
+// subtreeCTE selects the ids of a task and all of its descendants
+const subtreeCTE = `
+	WITH RECURSIVE subtree(id, depth) AS (
+		SELECT id, 0 FROM tasks WHERE id = $1
+		UNION ALL
+		SELECT t.id, s.depth + 1 FROM tasks t JOIN subtree s ON t.parent_id = s.id
+	)
+`
+
 type taskRepository struct {
-	db *sql.DB
+	db dbtx
 }
 
 func NewTaskRepository(db *sql.DB) domain.TaskRepository {
 	return &taskRepository{db: db}
 }
 
-func (r *taskRepository) Create(task *domain.Task) error {
-	query := `
-		INSERT INTO tasks (id, title, description, status, priority, user_id, project_id, due_date, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	`
-	_, err := r.db.Exec(query, task.ID, task.Title, task.Description, task.Status, task.Priority,
-		task.UserID, task.ProjectID, task.DueDate, task.CreatedAt, task.UpdatedAt)
-	return err
+type rowScanner interface {
+	Scan(dest ...interface{}) error
 }
 
-func (r *taskRepository) GetByID(id string) (*domain.Task, error) {
-	query := `
-		SELECT id, title, description, status, priority, user_id, project_id, due_date, created_at, updated_at
-		FROM tasks WHERE id = $1
-	`
+func scanTask(row rowScanner) (*domain.Task, error) {
 	task := &domain.Task{}
-	err := r.db.QueryRow(query, id).Scan(
+	err := row.Scan(
 		&task.ID, &task.Title, &task.Description, &task.Status, &task.Priority,
-		&task.UserID, &task.ProjectID, &task.DueDate, &task.CreatedAt, &task.UpdatedAt,
+		&task.UserID, &task.ProjectID, &task.ParentID, &task.DueDate, &task.DueAllDay, &task.DueTimezone, &task.RecurrenceRule, &task.EstimatedMinutes, &task.CompletedAt, &task.Position, &task.CreatedAt, &task.UpdatedAt,
+		&task.Version, &task.DeletedAt,
 	)
 	if err != nil {
 		return nil, err
@@ -41,46 +44,324 @@ func (r *taskRepository) GetByID(id string) (*domain.Task, error) {
 	return task, nil
 }
 
-func (r *taskRepository) GetByUserID(userID string) ([]*domain.Task, error) {
-	query := `
-		SELECT id, title, description, status, priority, user_id, project_id, due_date, created_at, updated_at
-		FROM tasks WHERE user_id = $1 ORDER BY created_at DESC
-	`
-	rows, err := r.db.Query(query, userID)
-	if err != nil {
-		return nil, err
-	}
+func scanTasks(rows *sql.Rows) ([]*domain.Task, error) {
 	defer rows.Close()
 
 	var tasks []*domain.Task
 	for rows.Next() {
-		task := &domain.Task{}
-		err := rows.Scan(
-			&task.ID, &task.Title, &task.Description, &task.Status, &task.Priority,
-			&task.UserID, &task.ProjectID, &task.DueDate, &task.CreatedAt, &task.UpdatedAt,
-		)
+		task, err := scanTask(rows)
 		if err != nil {
 			return nil, err
 		}
 		tasks = append(tasks, task)
 	}
+	return tasks, rows.Err()
+}
+
+// loadLabels fills in the label names of the given tasks with one query
+func (r *taskRepository) loadLabels(tasks ...*domain.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.Task, len(tasks))
+	ids := make([]string, len(tasks))
+	for i, task := range tasks {
+		task.Labels = []string{}
+		byID[task.ID] = task
+		ids[i] = task.ID
+	}
+
+	query := `
+		SELECT tl.task_id, l.name
+		FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+		WHERE tl.task_id = ANY($1::uuid[])
+		ORDER BY lower(l.name)
+	`
+	rows, err := r.db.Query(query, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskID, name string
+		if err := rows.Scan(&taskID, &name); err != nil {
+			return err
+		}
+		if task, ok := byID[taskID]; ok {
+			task.Labels = append(task.Labels, name)
+		}
+	}
+	return rows.Err()
+}
+
+// queryTasks runs a task query and loads the labels of the result
+func (r *taskRepository) queryTasks(query string, args ...interface{}) ([]*domain.Task, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	tasks, err := scanTasks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadLabels(tasks...); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 
+func (r *taskRepository) Create(task *domain.Task) error {
+	query := `
+		INSERT INTO tasks (id, title, description, status, priority, user_id, project_id, parent_id, due_date, due_all_day, due_timezone, recurrence_rule, estimated_minutes, completed_at, position, created_at, updated_at, version)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
+	`
+	_, err := r.db.Exec(query, task.ID, task.Title, task.Description, task.Status, task.Priority,
+		task.UserID, task.ProjectID, task.ParentID, task.DueDate, task.DueAllDay, task.DueTimezone, task.RecurrenceRule, task.EstimatedMinutes, task.CompletedAt, task.Position, task.CreatedAt, task.UpdatedAt, task.Version)
+	return err
+}
+
+func (r *taskRepository) GetByID(id string) (*domain.Task, error) {
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND deleted_at IS NULL`
+	task, err := scanTask(r.db.QueryRow(query, id))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadLabels(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *taskRepository) GetByUserID(userID string) ([]*domain.Task, error) {
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE user_id = $1 AND deleted_at IS NULL ORDER BY created_at DESC`
+	return r.queryTasks(query, userID)
+}
+
 func (r *taskRepository) Update(task *domain.Task) error {
 	query := `
 		UPDATE tasks
-		SET title = $1, description = $2, status = $3, priority = $4, project_id = $5, due_date = $6, updated_at = $7
-		WHERE id = $8
+		SET title = $1, description = $2, status = $3, priority = $4, project_id = $5, due_date = $6,
+			due_all_day = $7, due_timezone = $8, recurrence_rule = $9, estimated_minutes = $10, completed_at = $11, updated_at = $12, version = version + 1
+		WHERE id = $13 AND version = $14 AND deleted_at IS NULL
 	`
 	task.UpdatedAt = time.Now()
-	_, err := r.db.Exec(query, task.Title, task.Description, task.Status, task.Priority,
-		task.ProjectID, task.DueDate, task.UpdatedAt, task.ID)
-	return err
+	result, err := r.db.Exec(query, task.Title, task.Description, task.Status, task.Priority,
+		task.ProjectID, task.DueDate, task.DueAllDay, task.DueTimezone, task.RecurrenceRule, task.EstimatedMinutes, task.CompletedAt, task.UpdatedAt, task.ID, task.Version)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrTaskVersionConflict
+	}
+
+	task.Version++
+	return nil
 }
 
+// Delete trashes a task with its subtree. The whole subtree shares one
+// deleted_at, which is how Restore finds the descendants deleted with it.
 func (r *taskRepository) Delete(id string) error {
-	query := `DELETE FROM tasks WHERE id = $1`
-	_, err := r.db.Exec(query, id)
+	query := subtreeCTE + `
+		UPDATE tasks SET deleted_at = $2, updated_at = $2, version = version + 1
+		WHERE id IN (SELECT id FROM subtree) AND deleted_at IS NULL
+	`
+	_, err := r.db.Exec(query, id, time.Now())
+	return err
+}
+
+func (r *taskRepository) GetDeleted(id string) (*domain.Task, error) {
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE id = $1 AND deleted_at IS NOT NULL`
+	task, err := scanTask(r.db.QueryRow(query, id))
+	if err != nil {
+		return nil, err
+	}
+	if err := r.loadLabels(task); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
+
+func (r *taskRepository) GetTrash(userID string) ([]*domain.Task, error) {
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks t
+		WHERE t.user_id = $1 AND t.deleted_at IS NOT NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM tasks p
+				WHERE p.id = t.parent_id AND p.deleted_at = t.deleted_at
+			)
+		ORDER BY t.deleted_at DESC, t.id
+	`
+	return r.queryTasks(query, userID)
+}
+
+// Restore clears deleted_at on the task, on the descendants trashed in the
+// same delete and on its trashed ancestors, so a restored task is never
+// left under a parent that is still in the trash
+func (r *taskRepository) Restore(id string) ([]*domain.Task, error) {
+	var trashed []*domain.Task
+	err := inTx(r.db, func(tx dbtx) error {
+		repo := &taskRepository{db: tx}
+
+		query := `
+			WITH RECURSIVE deleted_with(id) AS (
+				SELECT id FROM tasks WHERE id = $1
+				UNION ALL
+				SELECT t.id FROM tasks t JOIN deleted_with d ON t.parent_id = d.id
+				WHERE t.deleted_at = (SELECT deleted_at FROM tasks WHERE id = $1)
+			), ancestors(id, next_id) AS (
+				SELECT id, parent_id FROM tasks WHERE id = $1
+				UNION ALL
+				SELECT t.id, t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.next_id
+			)
+			SELECT ` + taskColumns + `
+			FROM tasks
+			WHERE deleted_at IS NOT NULL
+				AND (id IN (SELECT id FROM deleted_with) OR id IN (SELECT id FROM ancestors))
+			FOR UPDATE
+		`
+		var err error
+		trashed, err = repo.queryTasks(query, id)
+		if err != nil || len(trashed) == 0 {
+			return err
+		}
+
+		ids := make([]string, len(trashed))
+		for i, task := range trashed {
+			ids[i] = task.ID
+		}
+		// A tree moved to another project while some of its subtasks were in
+		// the trash takes them along when they come back
+		_, err = tx.Exec(`
+			WITH RECURSIVE ancestors(id, next_id, project_id) AS (
+				SELECT id, parent_id, project_id FROM tasks WHERE id = $3
+				UNION ALL
+				SELECT t.id, t.parent_id, t.project_id FROM tasks t JOIN ancestors a ON t.id = a.next_id
+			)
+			UPDATE tasks SET deleted_at = NULL, updated_at = $2, version = version + 1,
+				project_id = (SELECT project_id FROM ancestors WHERE next_id IS NULL)
+			WHERE id = ANY($1::uuid[])
+		`, pq.Array(ids), time.Now(), id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trashed, nil
+}
+
+// PurgeDeleted hard-deletes trashed tasks. Descendants are removed by the
+// parent_id cascade; they were trashed no later than their parent.
+// PurgeDeleted leaves a tombstone for every purged task, so that clients
+// syncing from before the purge learn that it is gone
+func (r *taskRepository) PurgeDeleted(before time.Time) (int64, error) {
+	query := `
+		WITH purged AS (
+			DELETE FROM tasks WHERE deleted_at < $1 RETURNING id, user_id, deleted_at
+		)
+		INSERT INTO task_tombstones (task_id, user_id, deleted_at)
+		SELECT id, user_id, deleted_at FROM purged
+	`
+	result, err := r.db.Exec(query, before)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (r *taskRepository) GetSubtree(rootID string) ([]*domain.Task, error) {
+	query := subtreeCTE + `
+		SELECT ` + taskColumns + `
+		FROM tasks JOIN subtree USING (id)
+		WHERE tasks.deleted_at IS NULL
+		ORDER BY subtree.depth, tasks.created_at
+	`
+	return r.queryTasks(query, rootID)
+}
+
+func (r *taskRepository) GetAncestors(id string) ([]*domain.Task, error) {
+	query := `
+		WITH RECURSIVE ancestors(id, next_id, depth) AS (
+			SELECT id, parent_id, 0 FROM tasks WHERE id = $1
+			UNION ALL
+			SELECT t.id, t.parent_id, a.depth + 1 FROM tasks t JOIN ancestors a ON t.id = a.next_id
+		)
+		SELECT ` + taskColumns + `
+		FROM tasks JOIN ancestors USING (id)
+		WHERE ancestors.depth > 0 AND tasks.deleted_at IS NULL
+		ORDER BY ancestors.depth
+	`
+	return r.queryTasks(query, id)
+}
+
+func (r *taskRepository) MoveSubtree(rootID string, parentID, projectID *string) error {
+	return inTx(r.db, func(tx dbtx) error {
+		now := time.Now()
+		if _, err := tx.Exec(`UPDATE tasks SET parent_id = $1, updated_at = $2 WHERE id = $3`, parentID, now, rootID); err != nil {
+			return err
+		}
+
+		query := subtreeCTE + `
+			UPDATE tasks SET project_id = $2, updated_at = $3, version = version + 1
+			WHERE id IN (SELECT id FROM subtree) AND deleted_at IS NULL
+		`
+		_, err := tx.Exec(query, rootID, projectID, now)
+		return err
+	})
+}
+
+func (r *taskRepository) SetSubtreeStatus(rootID, status string) error {
+	query := subtreeCTE + `
+		UPDATE tasks SET status = $2, completed_at = CASE WHEN $2 = $4 THEN $3::timestamp END,
+			updated_at = $3, version = version + 1
+		WHERE id IN (SELECT id FROM subtree) AND status <> $2 AND deleted_at IS NULL
+	`
+	_, err := r.db.Exec(query, rootID, status, time.Now(), domain.TaskStatusCompleted)
+	return err
+}
+
+// The position column uses the "C" collation, so ranks order bytewise like
+// domain.RankBetween expects. IS NOT DISTINCT FROM matches the inbox too.
+
+func (r *taskRepository) LastPosition(userID string, projectID *string) (string, error) {
+	query := `
+		SELECT COALESCE(MAX(position), '') FROM tasks
+		WHERE user_id = $1 AND project_id IS NOT DISTINCT FROM $2
+	`
+	var position string
+	err := r.db.QueryRow(query, userID, projectID).Scan(&position)
+	return position, err
+}
+
+func (r *taskRepository) PositionBefore(userID string, projectID *string, position string) (string, error) {
+	query := `
+		SELECT COALESCE(MAX(position), '') FROM tasks
+		WHERE user_id = $1 AND project_id IS NOT DISTINCT FROM $2 AND position < $3
+	`
+	var before string
+	err := r.db.QueryRow(query, userID, projectID, position).Scan(&before)
+	return before, err
+}
+
+func (r *taskRepository) PositionAfter(userID string, projectID *string, position string) (string, error) {
+	query := `
+		SELECT COALESCE(MIN(position), '') FROM tasks
+		WHERE user_id = $1 AND project_id IS NOT DISTINCT FROM $2 AND position > $3
+	`
+	var after string
+	err := r.db.QueryRow(query, userID, projectID, position).Scan(&after)
+	return after, err
+}
+
+func (r *taskRepository) SetPosition(id, position string) error {
+	query := `UPDATE tasks SET position = $2, updated_at = $3, version = version + 1 WHERE id = $1`
+	_, err := r.db.Exec(query, id, position, time.Now())
 	return err
 }
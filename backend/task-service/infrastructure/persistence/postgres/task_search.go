@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"html"
+	"strings"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// ts_headline delimits matches with control characters rather than <mark>
+// tags, so that the snippet can be HTML-escaped before the tags are put in.
+// The characters are stripped from the text first.
+const (
+	highlightStart = "\x02"
+	highlightStop  = "\x03"
+)
+
+// headlineOptions configures ts_headline snippets
+const headlineOptions = "StartSel=" + highlightStart + ", StopSel=" + highlightStop + ", MaxWords=35, MinWords=15, MaxFragments=2"
+
+var highlightMarker = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// highlightHTML turns a ts_headline snippet into HTML with the matches
+// wrapped in <mark> tags
+func highlightHTML(snippet string) string {
+	return highlightMarker.Replace(html.EscapeString(snippet))
+}
+
+// prefixTSQuery builds a to_tsquery expression requiring every term as a
+// word prefix. Terms are letters and digits only, so they cannot inject
+// tsquery operators.
+func prefixTSQuery(terms []string) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = term + ":*"
+	}
+	return strings.Join(parts, " & ")
+}
+
+func (r *taskRepository) Search(userID string, terms []string, query domain.TaskQuery) ([]*domain.TaskSearchResult, error) {
+	where, err := buildTaskQuery(userID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	tsQuery := "to_tsquery('english', " + where.arg(prefixTSQuery(terms)) + ")"
+	options := where.arg(headlineOptions)
+	markers := where.arg(highlightStart + highlightStop)
+	where.add("(search_vector @@ q.query OR best_comment.content IS NOT NULL)")
+
+	// Comment matches count towards the rank at half the weight of the task's
+	// own text; only the best matching comment is highlighted
+	sqlQuery := `
+		SELECT ` + taskColumns + `,
+			ts_rank(search_vector, q.query) + COALESCE(best_comment.comment_rank, 0) / 2 AS rank,
+			CASE WHEN to_tsvector('english', title) @@ q.query
+				THEN ts_headline('english', translate(title, ` + markers + `, ''), q.query, ` + options + `) ELSE '' END,
+			CASE WHEN to_tsvector('english', COALESCE(description, '')) @@ q.query
+				THEN ts_headline('english', translate(COALESCE(description, ''), ` + markers + `, ''), q.query, ` + options + `) ELSE '' END,
+			COALESCE(ts_headline('english', translate(best_comment.content, ` + markers + `, ''), q.query, ` + options + `), '')
+		FROM tasks
+		CROSS JOIN (SELECT ` + tsQuery + ` AS query) q
+		LEFT JOIN LATERAL (
+			SELECT c.content, ts_rank(c.search_vector, q.query) AS comment_rank
+			FROM comments c
+			WHERE c.task_id = tasks.id AND c.search_vector @@ q.query
+			ORDER BY comment_rank DESC
+			LIMIT 1
+		) best_comment ON TRUE
+		WHERE ` + where.sql() + `
+		ORDER BY rank DESC, id`
+	if query.Limit > 0 {
+		sqlQuery += ` LIMIT ` + where.arg(query.Limit)
+	}
+
+	rows, err := r.db.Query(sqlQuery, where.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.TaskSearchResult
+	var tasks []*domain.Task
+	for rows.Next() {
+		task := &domain.Task{}
+		result := &domain.TaskSearchResult{Task: task}
+		err := rows.Scan(
+			&task.ID, &task.Title, &task.Description, &task.Status, &task.Priority,
+			&task.UserID, &task.ProjectID, &task.ParentID, &task.DueDate, &task.DueAllDay, &task.DueTimezone, &task.RecurrenceRule, &task.EstimatedMinutes, &task.CompletedAt, &task.Position, &task.CreatedAt, &task.UpdatedAt, &task.Version, &task.DeletedAt,
+			&result.Rank, &result.TitleHighlight, &result.DescriptionHighlight, &result.CommentHighlight,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result.TitleHighlight = highlightHTML(result.TitleHighlight)
+		result.DescriptionHighlight = highlightHTML(result.DescriptionHighlight)
+		result.CommentHighlight = highlightHTML(result.CommentHighlight)
+		results = append(results, result)
+		tasks = append(tasks, task)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadLabels(tasks...); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+const timeEntryColumns = `id, task_id, user_id, started_at, stopped_at, note, created_at`
+
+// uniqueViolation is the SQLSTATE of a unique index conflict
+const uniqueViolation = "23505"
+
+type timeEntryRepository struct {
+	db dbtx
+}
+
+func NewTimeEntryRepository(db *sql.DB) domain.TimeEntryRepository {
+	return &timeEntryRepository{db: db}
+}
+
+func scanTimeEntry(row rowScanner, extra ...interface{}) (*domain.TimeEntry, error) {
+	entry := &domain.TimeEntry{}
+	dest := append([]interface{}{
+		&entry.ID, &entry.TaskID, &entry.UserID, &entry.StartedAt, &entry.StoppedAt, &entry.Note, &entry.CreatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (r *timeEntryRepository) Create(entry *domain.TimeEntry) error {
+	query := `
+		INSERT INTO time_entries (id, task_id, user_id, started_at, stopped_at, note, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+	var stoppedAt *time.Time
+	if entry.StoppedAt != nil {
+		utc := entry.StoppedAt.UTC()
+		stoppedAt = &utc
+	}
+	_, err := r.db.Exec(query, entry.ID, entry.TaskID, entry.UserID, entry.StartedAt.UTC(), stoppedAt, entry.Note, entry.CreatedAt)
+
+	// Only the running timer index can conflict, ids are fresh
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolation {
+		return domain.ErrTimerRunning
+	}
+	return err
+}
+
+func (r *timeEntryRepository) GetByID(id string) (*domain.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE id = $1`
+	return scanTimeEntry(r.db.QueryRow(query, id))
+}
+
+func (r *timeEntryRepository) GetByTaskID(taskID string) ([]*domain.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE task_id = $1 ORDER BY started_at, id`
+	rows, err := r.db.Query(query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.TimeEntry
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+func (r *timeEntryRepository) GetRunning(userID string) (*domain.TimeEntry, error) {
+	query := `SELECT ` + timeEntryColumns + ` FROM time_entries WHERE user_id = $1 AND stopped_at IS NULL`
+	return scanTimeEntry(r.db.QueryRow(query, userID))
+}
+
+func (r *timeEntryRepository) Stop(id string, stoppedAt time.Time) error {
+	result, err := r.db.Exec(`UPDATE time_entries SET stopped_at = $2 WHERE id = $1 AND stopped_at IS NULL`, id, stoppedAt.UTC())
+	if err != nil {
+		return err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func (r *timeEntryRepository) Delete(id string) error {
+	_, err := r.db.Exec(`DELETE FROM time_entries WHERE id = $1`, id)
+	return err
+}
+
+func (r *timeEntryRepository) GetTracked(userID string, from, to time.Time) ([]*domain.TrackedTime, error) {
+	query := `
+		SELECT e.id, e.task_id, e.user_id, e.started_at, e.stopped_at, e.note, e.created_at, t.project_id,
+			ARRAY(
+				SELECT l.name FROM task_labels tl JOIN labels l ON l.id = tl.label_id
+				WHERE tl.task_id = t.id ORDER BY l.name
+			)
+		FROM time_entries e
+		JOIN tasks t ON t.id = e.task_id
+		WHERE e.user_id = $1 AND e.started_at < $3 AND (e.stopped_at IS NULL OR e.stopped_at > $2)
+		ORDER BY e.started_at, e.id
+	`
+	rows, err := r.db.Query(query, userID, from.UTC(), to.UTC())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tracked []*domain.TrackedTime
+	for rows.Next() {
+		t := &domain.TrackedTime{}
+		var labels []string
+		t.Entry, err = scanTimeEntry(rows, &t.ProjectID, pq.Array(&labels))
+		if err != nil {
+			return nil, err
+		}
+		t.Labels = labels
+		tracked = append(tracked, t)
+	}
+	return tracked, rows.Err()
+}
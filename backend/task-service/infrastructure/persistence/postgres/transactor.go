@@ -0,0 +1,43 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) domain.Transactor {
+	return &transactor{db: db}
+}
+
+func (t *transactor) WithinTransaction(ctx context.Context, fn func(repos domain.Repositories) error) error {
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	repos := domain.Repositories{
+		Tasks:        &taskRepository{db: tx},
+		Completions:  &taskCompletionRepository{db: tx},
+		Labels:       &labelRepository{db: tx},
+		Comments:     &commentRepository{db: tx},
+		Outbox:       &outboxRepository{db: tx},
+		Activity:     &activityRepository{db: tx},
+		Reminders:    &reminderRepository{db: tx},
+		Dependencies: &dependencyRepository{db: tx},
+		SyncCommands: &syncCommandRepository{db: tx},
+		Stats:        &statsRepository{db: tx},
+		Imports:      &taskImportRepository{db: tx},
+	}
+	if err := fn(repos); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,183 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// LocalBlobPath is where the task service serves the blobs of a LocalStore;
+// the blob key follows it
+const LocalBlobPath = "/tasks/attachments/blobs/"
+
+// LocalStore keeps blobs as files under a directory. It signs download URLs
+// itself, pointing them at LocalBlobPath below baseURL, and serves them as
+// an http.Handler.
+type LocalStore struct {
+	root    string
+	baseURL string
+	secret  []byte
+}
+
+// NewLocalStore creates a store under root. baseURL is the public URL the
+// task service is reached at, secret the key download URLs are signed with.
+func NewLocalStore(root, baseURL, secret string) (*LocalStore, error) {
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+	return &LocalStore{
+		root:    root,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		secret:  []byte(secret),
+	}, nil
+}
+
+// path maps a key to its file, rejecting keys that would leave the root
+func (s *LocalStore) path(key string) (string, error) {
+	if key == "" || strings.ContainsAny(key, `/\`) || key == "." || key == ".." {
+		return "", fmt.Errorf("invalid blob key %q", key)
+	}
+	// Spread files over subdirectories named after the key's first characters
+	prefix := key
+	if len(prefix) > 2 {
+		prefix = prefix[:2]
+	}
+	return filepath.Join(s.root, prefix, key), nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so readers never see a partial blob
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, body)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if written != size {
+		return fmt.Errorf("blob %s: wrote %d bytes, expected %d", key, written, size)
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	name, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.Open(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, domain.ErrBlobNotFound
+	}
+	return file, err
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	name, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(name); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *LocalStore) SignedURL(ctx context.Context, key, fileName, contentType string, expiry time.Duration) (string, error) {
+	if _, err := s.path(key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(time.Now().Add(expiry).Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"name":      {fileName},
+		"type":      {contentType},
+		"signature": {s.sign(key, expires, fileName, contentType)},
+	}
+	return s.baseURL + LocalBlobPath + url.PathEscape(key) + "?" + query.Encode(), nil
+}
+
+// sign is the hex HMAC-SHA256 over everything a download URL controls
+func (s *LocalStore) sign(key, expires, fileName, contentType string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(strings.Join([]string{key, expires, fileName, contentType}, "\n")))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ServeHTTP serves a blob to the holder of a signed URL. The content is
+// always sent as a download so that uploaded files never render in the
+// service's origin.
+func (s *LocalStore) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	key := path.Base(r.URL.Path)
+	query := r.URL.Query()
+	expires, fileName, contentType := query.Get("expires"), query.Get("name"), query.Get("type")
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	signature, decodeErr := hex.DecodeString(query.Get("signature"))
+	expected, _ := hex.DecodeString(s.sign(key, expires, fileName, contentType))
+	if err != nil || decodeErr != nil || !hmac.Equal(signature, expected) {
+		http.Error(w, "invalid signature", http.StatusForbidden)
+		return
+	}
+	if time.Now().Unix() > expiresAt {
+		http.Error(w, "link expired", http.StatusForbidden)
+		return
+	}
+
+	name, err := s.path(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	file, err := os.Open(name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		http.Error(w, "failed to read blob", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": fileName}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=0")
+	http.ServeContent(w, r, "", info.ModTime(), file)
+}
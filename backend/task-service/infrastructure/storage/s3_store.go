@@ -0,0 +1,190 @@
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// S3Config locates a bucket of an S3-compatible service such as MinIO.
+// PublicEndpoint is the endpoint clients download from when it differs from
+// the one the service talks to, as inside a container network.
+type S3Config struct {
+	Endpoint       string
+	PublicEndpoint string
+	Region         string
+	Bucket         string
+	AccessKey      string
+	SecretKey      string
+}
+
+// S3Store keeps blobs in an S3 bucket, addressed path-style so that it
+// works with MinIO without DNS setup. Requests are signed with AWS
+// Signature Version 4.
+type S3Store struct {
+	endpoint       *url.URL
+	publicEndpoint *url.URL
+	bucket         string
+	signer         *sigV4Signer
+	client         *http.Client
+}
+
+func NewS3Store(cfg S3Config) (*S3Store, error) {
+	endpoint, err := url.Parse(strings.TrimSuffix(cfg.Endpoint, "/"))
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid S3 endpoint %q", cfg.Endpoint)
+	}
+	publicEndpoint := endpoint
+	if cfg.PublicEndpoint != "" {
+		publicEndpoint, err = url.Parse(strings.TrimSuffix(cfg.PublicEndpoint, "/"))
+		if err != nil || publicEndpoint.Host == "" {
+			return nil, fmt.Errorf("invalid S3 public endpoint %q", cfg.PublicEndpoint)
+		}
+	}
+	if cfg.Bucket == "" {
+		return nil, errors.New("S3 bucket is required")
+	}
+
+	return &S3Store{
+		endpoint:       endpoint,
+		publicEndpoint: publicEndpoint,
+		bucket:         cfg.Bucket,
+		signer: &sigV4Signer{
+			accessKey: cfg.AccessKey,
+			secretKey: cfg.SecretKey,
+			region:    cfg.Region,
+			service:   "s3",
+		},
+		client: &http.Client{},
+	}, nil
+}
+
+// objectURL is the path-style URL of a key below endpoint; an empty key
+// addresses the bucket
+func (s *S3Store) objectURL(endpoint *url.URL, key string) *url.URL {
+	u := *endpoint
+	u.Path = endpoint.Path + "/" + s.bucket
+	if key != "" {
+		u.Path += "/" + key
+	}
+	u.RawPath = ""
+	return &u
+}
+
+// do signs and sends a request, turning error responses into errors
+func (s *S3Store) do(ctx context.Context, method, key string, body io.Reader, size int64, contentType string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, s.objectURL(s.endpoint, key).String(), body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.ContentLength = size
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.signer.signRequest(req, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		return resp, s3Error(method, key, resp)
+	}
+	return resp, nil
+}
+
+// s3Error reads the error code out of an S3 error response
+func s3Error(method, key string, resp *http.Response) error {
+	var s3Err struct {
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+	if err := xml.Unmarshal(body, &s3Err); err != nil || s3Err.Code == "" {
+		return fmt.Errorf("s3 %s %s: %s", method, key, resp.Status)
+	}
+	return fmt.Errorf("s3 %s %s: %s: %s (%s)", method, key, resp.Status, s3Err.Code, s3Err.Message)
+}
+
+// EnsureBucket creates the bucket unless it exists already
+func (s *S3Store) EnsureBucket(ctx context.Context) error {
+	resp, err := s.do(ctx, http.MethodHead, "", nil, 0, "")
+	if err == nil {
+		resp.Body.Close()
+		return nil
+	}
+	if resp == nil || resp.StatusCode != http.StatusNotFound {
+		return err
+	}
+
+	resp, err = s.do(ctx, http.MethodPut, "", nil, 0, "")
+	if err != nil {
+		// Another replica may have created it in the meantime
+		if resp != nil && resp.StatusCode == http.StatusConflict {
+			return nil
+		}
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	// An empty body must still be sent with a zero Content-Length
+	if size == 0 {
+		body = http.NoBody
+	}
+	resp, err := s.do(ctx, http.MethodPut, key, body, size, contentType)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil, 0, "")
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, domain.ErrBlobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	resp, err := s.do(ctx, http.MethodDelete, key, nil, 0, "")
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// SignedURL presigns a GET on the public endpoint. The response headers
+// make the browser download the blob under its file name.
+func (s *S3Store) SignedURL(ctx context.Context, key, fileName, contentType string, expiry time.Duration) (string, error) {
+	u := s.objectURL(s.publicEndpoint, key)
+	query := url.Values{
+		"response-content-disposition": {mime.FormatMediaType("attachment", map[string]string{"filename": fileName})},
+		"response-content-type":        {contentType},
+	}
+	u.RawQuery = query.Encode()
+	return s.signer.presign(u, time.Now(), expiry), nil
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type ActivityHandler struct {
+	baseHandler
+	getActivityUC *usecase.GetActivityUseCase
+}
+
+func NewActivityHandler(
+	log *logger.Logger,
+	taskRepo domain.TaskRepository,
+	activityRepo domain.ActivityRepository,
+) *ActivityHandler {
+	return &ActivityHandler{
+		baseHandler:   baseHandler{logger: log},
+		getActivityUC: usecase.NewGetActivityUseCase(taskRepo, activityRepo),
+	}
+}
+
+// GetUserActivity handles GET /tasks/activity, the feed of all of the user's
+// task activity
+func (h *ActivityHandler) GetUserActivity(w http.ResponseWriter, r *http.Request) {
+	h.listActivity(w, r, "")
+}
+
+// GetTaskActivity handles GET /tasks/{id}/activity
+func (h *ActivityHandler) GetTaskActivity(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	h.listActivity(w, r, vars["id"])
+}
+
+func (h *ActivityHandler) listActivity(w http.ResponseWriter, r *http.Request, taskID string) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	req := dto.ListActivityRequest{
+		TaskID: taskID,
+		Cursor: query.Get("cursor"),
+	}
+
+	limit, err := parseLimit(query)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	req.Limit = limit
+
+	// Get activity
+	activity, err := h.getActivityUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get activity")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, activity)
+}
@@ -0,0 +1,237 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// multipartOverhead is the room left in an upload's body limit for the
+// multipart framing and form fields around the file
+const multipartOverhead = 1 << 20
+
+// maxFormFieldSize bounds a form field other than the file
+const maxFormFieldSize = 1 << 10
+
+// UploadTimeout replaces the server's read and write timeouts for an
+// upload: a large file on a slow connection takes minutes
+const UploadTimeout = 10 * time.Minute
+
+type AttachmentHandler struct {
+	baseHandler
+	maxSize              int64
+	blobServer           http.Handler
+	uploadAttachmentUC   *usecase.UploadAttachmentUseCase
+	getTaskAttachmentsUC *usecase.GetTaskAttachmentsUseCase
+	getAttachmentUC      *usecase.GetAttachmentUseCase
+	deleteAttachmentUC   *usecase.DeleteAttachmentUseCase
+}
+
+// NewAttachmentHandler creates the attachment handler. blobServer serves
+// signed download URLs of blob stores that do not serve their own, and may
+// be nil.
+func NewAttachmentHandler(
+	log *logger.Logger,
+	taskRepo domain.TaskRepository,
+	commentRepo domain.CommentRepository,
+	attachmentRepo domain.AttachmentRepository,
+	blobStore domain.BlobStore,
+	blobServer http.Handler,
+	maxSize int64,
+	urlExpiry time.Duration,
+) *AttachmentHandler {
+	return &AttachmentHandler{
+		baseHandler:          baseHandler{logger: log},
+		maxSize:              maxSize,
+		blobServer:           blobServer,
+		uploadAttachmentUC:   usecase.NewUploadAttachmentUseCase(taskRepo, commentRepo, attachmentRepo, blobStore, maxSize, urlExpiry),
+		getTaskAttachmentsUC: usecase.NewGetTaskAttachmentsUseCase(taskRepo, attachmentRepo, blobStore, urlExpiry),
+		getAttachmentUC:      usecase.NewGetAttachmentUseCase(taskRepo, attachmentRepo, blobStore, urlExpiry),
+		deleteAttachmentUC:   usecase.NewDeleteAttachmentUseCase(taskRepo, attachmentRepo, blobStore),
+	}
+}
+
+// UploadAttachment handles POST /tasks/{id}/attachments. The body is
+// multipart/form-data with the file in a "file" part and an optional
+// "comment_id" field, which must come before the file: the file is read
+// as it arrives, spooled to a temporary file and then stored.
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, h.maxSize+multipartOverhead)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "request body must be multipart/form-data")
+		return
+	}
+
+	var req dto.UploadAttachmentRequest
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			h.respondWithError(w, http.StatusBadRequest, "file is required")
+			return
+		}
+		if err != nil {
+			h.respondWithUploadError(w, err)
+			return
+		}
+
+		switch part.FormName() {
+		case "comment_id":
+			value, err := io.ReadAll(io.LimitReader(part, maxFormFieldSize))
+			if err != nil {
+				h.respondWithUploadError(w, err)
+				return
+			}
+			if commentID := strings.TrimSpace(string(value)); commentID != "" {
+				req.CommentID = &commentID
+			}
+		case "file":
+			req.FileName = part.FileName()
+			req.Content = part
+
+			// Upload attachment
+			attachment, err := h.uploadAttachmentUC.Execute(r.Context(), taskID, userID, req)
+			if err != nil {
+				h.respondWithUseCaseError(w, err, "failed to upload attachment")
+				return
+			}
+
+			h.respondWithJSON(w, http.StatusCreated, attachment)
+			return
+		}
+	}
+}
+
+// respondWithUploadError reports a malformed or oversized upload body
+func (h *AttachmentHandler) respondWithUploadError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		h.respondWithError(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+	h.respondWithError(w, http.StatusBadRequest, "invalid multipart body")
+}
+
+// GetTaskAttachments handles GET /tasks/{id}/attachments
+func (h *AttachmentHandler) GetTaskAttachments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get attachments
+	attachments, err := h.getTaskAttachmentsUC.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get attachments")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"data":  attachments,
+		"total": len(attachments),
+	})
+}
+
+// GetAttachment handles GET /tasks/{id}/attachments/{attachmentId}
+func (h *AttachmentHandler) GetAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	attachmentID := vars["attachmentId"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get attachment
+	attachment, err := h.getAttachmentUC.Execute(r.Context(), taskID, attachmentID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get attachment")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, attachment)
+}
+
+// DownloadAttachment handles GET /tasks/{id}/attachments/{attachmentId}/download
+// by redirecting to a freshly signed download URL
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	attachmentID := vars["attachmentId"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get attachment
+	attachment, err := h.getAttachmentUC.Execute(r.Context(), taskID, attachmentID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get attachment")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	http.Redirect(w, r, attachment.DownloadURL, http.StatusFound)
+}
+
+// DeleteAttachment handles DELETE /tasks/{id}/attachments/{attachmentId}
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	attachmentID := vars["attachmentId"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Delete attachment
+	if err := h.deleteAttachmentUC.Execute(r.Context(), taskID, attachmentID, userID); err != nil {
+		h.respondWithUseCaseError(w, err, "failed to delete attachment")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]string{"message": "attachment deleted", "id": attachmentID})
+}
+
+// ServeBlob handles GET /tasks/attachments/blobs/{key}, the signed download
+// URLs of a blob store served by the task service. The signature in the URL
+// stands in for a token.
+func (h *AttachmentHandler) ServeBlob(w http.ResponseWriter, r *http.Request) {
+	if h.blobServer == nil {
+		h.respondWithError(w, http.StatusNotFound, "not found")
+		return
+	}
+	h.blobServer.ServeHTTP(w, r)
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	apperrors "github.com/todoist/backend/pkg/errors"
+	"github.com/todoist/backend/pkg/logger"
+)
+
+// baseHandler holds the response helpers shared by all handlers
+type baseHandler struct {
+	logger *logger.Logger
+}
+
+func (h *baseHandler) respondWithError(w http.ResponseWriter, code int, message string) {
+	h.respondWithJSON(w, code, map[string]string{"error": message})
+}
+
+// respondWithUseCaseError responds with the status of an application error,
+// falling back to a 500 with the given message for unexpected errors
+func (h *baseHandler) respondWithUseCaseError(w http.ResponseWriter, err error, fallback string) {
+	var appErr *apperrors.AppError
+	if errors.As(err, &appErr) && appErr.StatusCode != http.StatusInternalServerError {
+		h.respondWithError(w, appErr.StatusCode, appErr.Message)
+		return
+	}
+
+	h.logger.WithError(err).Error(fallback)
+	h.respondWithError(w, http.StatusInternalServerError, fallback)
+}
+
+func (h *baseHandler) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
+	response, _ := json.Marshal(payload)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(response)
+}
+
+func (h *baseHandler) getUserIDFromToken(r *http.Request) (string, error) {
+	// Get user ID from header set by API gateway
+	userID := r.Header.Get("X-User-ID")
+	if userID == "" {
+		return "", fmt.Errorf("X-User-ID header is required")
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type CalendarHandler struct {
+	baseHandler
+	createTokenUC *usecase.CreateCalendarTokenUseCase
+	revokeTokenUC *usecase.RevokeCalendarTokenUseCase
+	getFeedUC     *usecase.GetCalendarFeedUseCase
+}
+
+// NewCalendarHandler creates the calendar feed handler. publicURL is the
+// base URL clients reach the tasks API at, used to build feed URLs.
+func NewCalendarHandler(log *logger.Logger, taskRepo domain.TaskRepository, feedRepo domain.CalendarFeedRepository, publicURL string) *CalendarHandler {
+	return &CalendarHandler{
+		baseHandler:   baseHandler{logger: log},
+		createTokenUC: usecase.NewCreateCalendarTokenUseCase(feedRepo, publicURL),
+		revokeTokenUC: usecase.NewRevokeCalendarTokenUseCase(feedRepo),
+		getFeedUC:     usecase.NewGetCalendarFeedUseCase(feedRepo, taskRepo),
+	}
+}
+
+// CreateToken handles POST /tasks/calendar/token
+func (h *CalendarHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Create token
+	token, err := h.createTokenUC.Execute(r.Context(), userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to create calendar token")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, token)
+}
+
+// RevokeToken handles DELETE /tasks/calendar/token
+func (h *CalendarHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Revoke token
+	if err := h.revokeTokenUC.Execute(r.Context(), userID); err != nil {
+		h.respondWithUseCaseError(w, err, "failed to revoke calendar token")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]string{"message": "calendar token revoked"})
+}
+
+// GetFeed handles GET /tasks/calendar.ics. Calendar apps cannot send a JWT,
+// so the feed token in the query stands in for one. The feed can be narrowed
+// with project_id, comma separated labels and components.
+func (h *CalendarHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	req := dto.CalendarFeedRequest{
+		Token:      query.Get("token"),
+		Components: query.Get("components"),
+	}
+	if projectID := query.Get("project_id"); projectID != "" {
+		req.ProjectID = &projectID
+	}
+	if labelsStr := query.Get("labels"); labelsStr != "" {
+		req.Labels = strings.Split(labelsStr, ",")
+	}
+
+	// Render feed
+	feed, err := h.getFeedUC.Execute(r.Context(), req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get calendar feed")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+	w.Write(feed)
+}
@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/pkg/validator"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type CommentHandler struct {
+	baseHandler
+	validator         *validator.Validator
+	addCommentUC      *usecase.AddCommentUseCase
+	getTaskCommentsUC *usecase.GetTaskCommentsUseCase
+	updateCommentUC   *usecase.UpdateCommentUseCase
+	deleteCommentUC   *usecase.DeleteCommentUseCase
+}
+
+func NewCommentHandler(
+	v *validator.Validator,
+	log *logger.Logger,
+	transactor domain.Transactor,
+	taskRepo domain.TaskRepository,
+	commentRepo domain.CommentRepository,
+) *CommentHandler {
+	return &CommentHandler{
+		baseHandler:       baseHandler{logger: log},
+		validator:         v,
+		addCommentUC:      usecase.NewAddCommentUseCase(transactor),
+		getTaskCommentsUC: usecase.NewGetTaskCommentsUseCase(taskRepo, commentRepo),
+		updateCommentUC:   usecase.NewUpdateCommentUseCase(taskRepo, commentRepo),
+		deleteCommentUC:   usecase.NewDeleteCommentUseCase(taskRepo, commentRepo),
+	}
+}
+
+func (h *CommentHandler) AddComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	var req dto.CreateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Add comment
+	comment, err := h.addCommentUC.Execute(r.Context(), taskID, userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to add comment")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, comment)
+}
+
+func (h *CommentHandler) GetTaskComments(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get comments
+	comments, err := h.getTaskCommentsUC.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get comments")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"data":  comments,
+		"total": len(comments),
+	})
+}
+
+func (h *CommentHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	commentID := vars["commentId"]
+
+	var req dto.UpdateCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Update comment
+	comment, err := h.updateCommentUC.Execute(r.Context(), taskID, commentID, userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to update comment")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, comment)
+}
+
+func (h *CommentHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	commentID := vars["commentId"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Delete comment
+	if err := h.deleteCommentUC.Execute(r.Context(), taskID, commentID, userID); err != nil {
+		h.respondWithUseCaseError(w, err, "failed to delete comment")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]string{"message": "comment deleted", "id": commentID})
+}
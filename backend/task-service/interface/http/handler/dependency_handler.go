@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/pkg/validator"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type DependencyHandler struct {
+	baseHandler
+	validator             *validator.Validator
+	addDependencyUC       *usecase.AddDependencyUseCase
+	getTaskDependenciesUC *usecase.GetTaskDependenciesUseCase
+	removeDependencyUC    *usecase.RemoveDependencyUseCase
+}
+
+func NewDependencyHandler(
+	v *validator.Validator,
+	log *logger.Logger,
+	transactor domain.Transactor,
+	taskRepo domain.TaskRepository,
+	dependencyRepo domain.DependencyRepository,
+) *DependencyHandler {
+	return &DependencyHandler{
+		baseHandler:           baseHandler{logger: log},
+		validator:             v,
+		addDependencyUC:       usecase.NewAddDependencyUseCase(transactor),
+		getTaskDependenciesUC: usecase.NewGetTaskDependenciesUseCase(taskRepo, dependencyRepo),
+		removeDependencyUC:    usecase.NewRemoveDependencyUseCase(taskRepo, dependencyRepo),
+	}
+}
+
+// AddDependency handles POST /tasks/{id}/dependencies
+func (h *DependencyHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	var req dto.AddDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Add dependency
+	dependency, err := h.addDependencyUC.Execute(r.Context(), taskID, userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to add dependency")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, dependency)
+}
+
+// GetTaskDependencies handles GET /tasks/{id}/dependencies
+func (h *DependencyHandler) GetTaskDependencies(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get dependencies
+	dependencies, err := h.getTaskDependenciesUC.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get dependencies")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, dependencies)
+}
+
+// RemoveDependency handles DELETE /tasks/{id}/dependencies/{blockerId}
+func (h *DependencyHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	blockerID := vars["blockerId"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Remove dependency
+	if err := h.removeDependencyUC.Execute(r.Context(), taskID, blockerID, userID); err != nil {
+		h.respondWithUseCaseError(w, err, "failed to remove dependency")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]string{"message": "dependency removed", "blocked_by_id": blockerID})
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// maxImportBodySize bounds the body of POST /tasks/import
+const maxImportBodySize = 64 << 20
+
+// maxMappingSize bounds the mapping field of an import
+const maxMappingSize = 16 << 10
+
+// ImportTimeout replaces the server's read and write timeouts for an
+// import: a large file takes minutes, and the client waits for its report
+const ImportTimeout = 30 * time.Minute
+
+type ImportHandler struct {
+	baseHandler
+	importTasksUC *usecase.ImportTasksUseCase
+}
+
+func NewImportHandler(log *logger.Logger, transactor domain.Transactor, importRepo domain.TaskImportRepository) *ImportHandler {
+	return &ImportHandler{
+		baseHandler:   baseHandler{logger: log},
+		importTasksUC: usecase.NewImportTasksUseCase(transactor, importRepo),
+	}
+}
+
+// ImportTasks handles POST /tasks/import. The body is multipart/form-data
+// with the CSV in a "file" part, after the optional "format", "dry_run",
+// "project_id", "timezone" and "mapping" (a JSON object) fields: the file is
+// imported as it arrives rather than buffered.
+func (h *ImportHandler) ImportTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportBodySize)
+	reader, err := r.MultipartReader()
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "request body must be multipart/form-data")
+		return
+	}
+
+	var req dto.ImportTasksRequest
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			h.respondWithError(w, http.StatusBadRequest, "file is required")
+			return
+		}
+		if err != nil {
+			h.respondWithImportError(w, err)
+			return
+		}
+
+		if part.FormName() == "file" {
+			req.Content = part
+			break
+		}
+
+		limit := int64(maxFormFieldSize)
+		if part.FormName() == "mapping" {
+			limit = maxMappingSize
+		}
+		raw, err := io.ReadAll(io.LimitReader(part, limit))
+		if err != nil {
+			h.respondWithImportError(w, err)
+			return
+		}
+		value := strings.TrimSpace(string(raw))
+
+		switch part.FormName() {
+		case "format":
+			req.Format = value
+		case "dry_run":
+			if req.DryRun, err = strconv.ParseBool(value); err != nil {
+				h.respondWithError(w, http.StatusBadRequest, "invalid dry_run")
+				return
+			}
+		case "project_id":
+			req.ProjectID = &value
+		case "timezone":
+			req.Timezone = value
+		case "mapping":
+			if err := json.Unmarshal(raw, &req.Mapping); err != nil {
+				h.respondWithError(w, http.StatusBadRequest, "mapping must be a JSON object of field to column")
+				return
+			}
+		}
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"user_id": userID,
+		"format":  req.Format,
+		"dry_run": req.DryRun,
+	}).Info("importing tasks")
+
+	// Import tasks
+	response, err := h.importTasksUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to import tasks")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, response)
+}
+
+// respondWithImportError reports a malformed or oversized import body
+func (h *ImportHandler) respondWithImportError(w http.ResponseWriter, err error) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		h.respondWithError(w, http.StatusRequestEntityTooLarge, "request body too large")
+		return
+	}
+	h.respondWithError(w, http.StatusBadRequest, "invalid multipart body")
+}
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/pkg/validator"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type LabelHandler struct {
+	baseHandler
+	validator       *validator.Validator
+	createLabelUC   *usecase.CreateLabelUseCase
+	getLabelUC      *usecase.GetLabelUseCase
+	getUserLabelsUC *usecase.GetUserLabelsUseCase
+	updateLabelUC   *usecase.UpdateLabelUseCase
+	deleteLabelUC   *usecase.DeleteLabelUseCase
+}
+
+func NewLabelHandler(
+	v *validator.Validator,
+	log *logger.Logger,
+	transactor domain.Transactor,
+	labelRepo domain.LabelRepository,
+) *LabelHandler {
+	return &LabelHandler{
+		baseHandler:     baseHandler{logger: log},
+		validator:       v,
+		createLabelUC:   usecase.NewCreateLabelUseCase(labelRepo),
+		getLabelUC:      usecase.NewGetLabelUseCase(labelRepo),
+		getUserLabelsUC: usecase.NewGetUserLabelsUseCase(labelRepo),
+		updateLabelUC:   usecase.NewUpdateLabelUseCase(transactor),
+		deleteLabelUC:   usecase.NewDeleteLabelUseCase(transactor),
+	}
+}
+
+func (h *LabelHandler) CreateLabel(w http.ResponseWriter, r *http.Request) {
+	var req dto.CreateLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Create label
+	label, err := h.createLabelUC.Execute(r.Context(), req, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to create label")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, label)
+}
+
+func (h *LabelHandler) GetLabel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	labelID := vars["labelId"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get label
+	label, err := h.getLabelUC.Execute(r.Context(), labelID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get label")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, label)
+}
+
+func (h *LabelHandler) GetUserLabels(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get labels
+	labels, err := h.getUserLabelsUC.Execute(r.Context(), userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get labels")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"data":  labels,
+		"total": len(labels),
+	})
+}
+
+func (h *LabelHandler) UpdateLabel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	labelID := vars["labelId"]
+
+	var req dto.UpdateLabelRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Update label
+	label, err := h.updateLabelUC.Execute(r.Context(), labelID, userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to update label")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, label)
+}
+
+func (h *LabelHandler) DeleteLabel(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	labelID := vars["labelId"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Delete label
+	if err := h.deleteLabelUC.Execute(r.Context(), labelID, userID); err != nil {
+		h.respondWithUseCaseError(w, err, "failed to delete label")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]string{"message": "label deleted", "id": labelID})
+}
@@ -0,0 +1,103 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type ReminderHandler struct {
+	baseHandler
+	createReminderUC   *usecase.CreateReminderUseCase
+	getTaskRemindersUC *usecase.GetTaskRemindersUseCase
+	deleteReminderUC   *usecase.DeleteReminderUseCase
+}
+
+func NewReminderHandler(log *logger.Logger, taskRepo domain.TaskRepository, reminderRepo domain.ReminderRepository) *ReminderHandler {
+	return &ReminderHandler{
+		baseHandler:        baseHandler{logger: log},
+		createReminderUC:   usecase.NewCreateReminderUseCase(taskRepo, reminderRepo),
+		getTaskRemindersUC: usecase.NewGetTaskRemindersUseCase(taskRepo, reminderRepo),
+		deleteReminderUC:   usecase.NewDeleteReminderUseCase(taskRepo, reminderRepo),
+	}
+}
+
+// CreateReminder handles POST /tasks/{id}/reminders
+func (h *ReminderHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	var req dto.CreateReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Add reminder
+	reminder, err := h.createReminderUC.Execute(r.Context(), taskID, userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to create reminder")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, reminder)
+}
+
+// GetTaskReminders handles GET /tasks/{id}/reminders
+func (h *ReminderHandler) GetTaskReminders(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get reminders
+	reminders, err := h.getTaskRemindersUC.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get reminders")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"data":  reminders,
+		"total": len(reminders),
+	})
+}
+
+// DeleteReminder handles DELETE /tasks/{id}/reminders/{reminderId}
+func (h *ReminderHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	reminderID := vars["reminderId"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Delete reminder
+	if err := h.deleteReminderUC.Execute(r.Context(), taskID, reminderID, userID); err != nil {
+		h.respondWithUseCaseError(w, err, "failed to delete reminder")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]string{"message": "reminder deleted", "id": reminderID})
+}
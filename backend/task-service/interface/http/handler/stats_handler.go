@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type StatsHandler struct {
+	baseHandler
+	getStatsUC    *usecase.GetStatsUseCase
+	updateGoalsUC *usecase.UpdateStatsGoalsUseCase
+}
+
+func NewStatsHandler(log *logger.Logger, transactor domain.Transactor, statsRepo domain.StatsRepository) *StatsHandler {
+	return &StatsHandler{
+		baseHandler:   baseHandler{logger: log},
+		getStatsUC:    usecase.NewGetStatsUseCase(statsRepo),
+		updateGoalsUC: usecase.NewUpdateStatsGoalsUseCase(transactor, statsRepo),
+	}
+}
+
+// GetStats handles GET /tasks/stats
+func (h *StatsHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	query := r.URL.Query()
+	var req dto.StatsRequest
+	if req.Days, err = parseCount(query, "days"); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid days")
+		return
+	}
+	if req.Weeks, err = parseCount(query, "weeks"); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid weeks")
+		return
+	}
+
+	// Get stats
+	stats, err := h.getStatsUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get stats")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, stats)
+}
+
+// UpdateGoals handles PUT /tasks/stats/goals
+func (h *StatsHandler) UpdateGoals(w http.ResponseWriter, r *http.Request) {
+	var req dto.UpdateStatsGoalsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Update goals
+	stats, err := h.updateGoalsUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to update goals")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, stats)
+}
+
+// parseCount reads an optional integer query parameter, 0 when absent
+func parseCount(query url.Values, name string) (int, error) {
+	value := query.Get(name)
+	if value == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(value)
+}
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+// maxSyncBodySize bounds the body of POST /sync
+const maxSyncBodySize = 4 << 20
+
+type SyncHandler struct {
+	baseHandler
+	syncUC *usecase.SyncUseCase
+}
+
+func NewSyncHandler(log *logger.Logger, transactor domain.Transactor, syncRepo domain.SyncRepository) *SyncHandler {
+	return &SyncHandler{
+		baseHandler: baseHandler{logger: log},
+		syncUC:      usecase.NewSyncUseCase(transactor, syncRepo),
+	}
+}
+
+// Sync handles POST /sync
+func (h *SyncHandler) Sync(w http.ResponseWriter, r *http.Request) {
+	var req dto.SyncRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxSyncBodySize)).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Apply commands and read changes
+	response, err := h.syncUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to sync")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, response)
+}
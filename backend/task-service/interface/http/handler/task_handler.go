@@ -2,44 +2,83 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/todoist/backend/pkg/jwt"
 	"github.com/todoist/backend/pkg/logger"
 	"github.com/todoist/backend/pkg/validator"
 	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/patch"
 	"github.com/todoist/backend/task-service/application/usecase"
 	"github.com/todoist/backend/task-service/domain"
+	"github.com/todoist/backend/task-service/domain/filter"
 )
 
 type TaskHandler struct {
-	validator       *validator.Validator
-	logger         *logger.Logger
+	baseHandler
+	validator      *validator.Validator
 	createTaskUC   *usecase.CreateTaskUseCase
 	getTaskUC      *usecase.GetTaskUseCase
 	getUserTasksUC *usecase.GetUserTasksUseCase
+	searchTasksUC  *usecase.SearchTasksUseCase
 	updateTaskUC   *usecase.UpdateTaskUseCase
+	patchTaskUC    *usecase.PatchTaskUseCase
 	deleteTaskUC   *usecase.DeleteTaskUseCase
+	restoreTaskUC  *usecase.RestoreTaskUseCase
+	getTrashUC     *usecase.GetTrashUseCase
+	closeTaskUC    *usecase.CloseTaskUseCase
+	reopenTaskUC   *usecase.ReopenTaskUseCase
+	getCompletedUC *usecase.GetCompletedTasksUseCase
+	batchTasksUC   *usecase.BatchTasksUseCase
+	quickAddUC     *usecase.QuickAddTaskUseCase
+	moveTaskUC     *usecase.MoveTaskUseCase
+	getTaskTreeUC  *usecase.GetTaskTreeUseCase
+	completionsUC  *usecase.GetTaskCompletionsUseCase
 	jwtService     *jwt.Service
+	// requireIfMatch rejects writes without an If-Match header
+	requireIfMatch bool
 }
 
 func NewTaskHandler(
 	v *validator.Validator,
 	log *logger.Logger,
+	transactor domain.Transactor,
 	taskRepo domain.TaskRepository,
+	completionRepo domain.TaskCompletionRepository,
+	projectLookup domain.ProjectLookup,
 	jwtService *jwt.Service,
+	requireIfMatch bool,
 ) *TaskHandler {
 	return &TaskHandler{
-		validator:       v,
-		logger:         log,
-		createTaskUC:   usecase.NewCreateTaskUseCase(taskRepo),
+		baseHandler:    baseHandler{logger: log},
+		validator:      v,
+		createTaskUC:   usecase.NewCreateTaskUseCase(transactor),
 		getTaskUC:      usecase.NewGetTaskUseCase(taskRepo),
-		getUserTasksUC: usecase.NewGetUserTasksUseCase(taskRepo),
-		updateTaskUC:   usecase.NewUpdateTaskUseCase(taskRepo),
-		deleteTaskUC:   usecase.NewDeleteTaskUseCase(taskRepo),
+		getUserTasksUC: usecase.NewGetUserTasksUseCase(taskRepo, projectLookup),
+		searchTasksUC:  usecase.NewSearchTasksUseCase(taskRepo, projectLookup),
+		updateTaskUC:   usecase.NewUpdateTaskUseCase(transactor),
+		patchTaskUC:    usecase.NewPatchTaskUseCase(transactor),
+		deleteTaskUC:   usecase.NewDeleteTaskUseCase(transactor),
+		restoreTaskUC:  usecase.NewRestoreTaskUseCase(transactor),
+		getTrashUC:     usecase.NewGetTrashUseCase(taskRepo),
+		closeTaskUC:    usecase.NewCloseTaskUseCase(transactor),
+		reopenTaskUC:   usecase.NewReopenTaskUseCase(transactor),
+		getCompletedUC: usecase.NewGetCompletedTasksUseCase(taskRepo),
+		batchTasksUC:   usecase.NewBatchTasksUseCase(transactor),
+		quickAddUC:     usecase.NewQuickAddTaskUseCase(transactor, projectLookup),
+		moveTaskUC:     usecase.NewMoveTaskUseCase(transactor),
+		getTaskTreeUC:  usecase.NewGetTaskTreeUseCase(taskRepo),
+		completionsUC:  usecase.NewGetTaskCompletionsUseCase(taskRepo, completionRepo),
 		jwtService:     jwtService,
+		requireIfMatch: requireIfMatch,
 	}
 }
 
@@ -73,12 +112,11 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 	// Create task
 	task, err := h.createTaskUC.Execute(r.Context(), req, userID)
 	if err != nil {
-		h.logger.WithError(err).Error("failed to create task in use case")
-		h.respondWithError(w, http.StatusInternalServerError, "failed to create task")
+		h.respondWithUseCaseError(w, err, "failed to create task")
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusCreated, task)
+	h.respondWithTask(w, http.StatusCreated, task)
 }
 
 func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
@@ -95,14 +133,41 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 	// Get task
 	task, err := h.getTaskUC.Execute(r.Context(), taskID, userID)
 	if err != nil {
-		h.respondWithError(w, http.StatusInternalServerError, "failed to get task")
+		h.respondWithUseCaseError(w, err, "failed to get task")
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusOK, task)
+	// Let clients revalidate cached copies
+	if r.Header.Get("If-None-Match") == taskETag(task) {
+		w.Header().Set("ETag", taskETag(task))
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	h.respondWithTask(w, http.StatusOK, task)
 }
 
 func (h *TaskHandler) GetUserTasks(w http.ResponseWriter, r *http.Request) {
+	h.listTasks(w, r, nil)
+}
+
+// GetBlockedTasks handles GET /tasks/blocked, the open tasks that wait on
+// an open blocker
+func (h *TaskHandler) GetBlockedTasks(w http.ResponseWriter, r *http.Request) {
+	blocked := true
+	h.listTasks(w, r, &blocked)
+}
+
+// GetReadyTasks handles GET /tasks/ready, the open tasks that wait on
+// nothing
+func (h *TaskHandler) GetReadyTasks(w http.ResponseWriter, r *http.Request) {
+	blocked := false
+	h.listTasks(w, r, &blocked)
+}
+
+// listTasks serves a task listing. A non-nil blocked narrows it to open
+// tasks that are or are not blocked, whatever the query says.
+func (h *TaskHandler) listTasks(w http.ResponseWriter, r *http.Request, blocked *bool) {
 	// Get user ID from JWT token
 	userID, err := h.getUserIDFromToken(r)
 	if err != nil {
@@ -111,32 +176,33 @@ func (h *TaskHandler) GetUserTasks(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Parse query parameters
-	status := r.URL.Query().Get("status")
-	priorityStr := r.URL.Query().Get("priority")
-	projectID := r.URL.Query().Get("project_id")
-
-	var priority *int
-	if priorityStr != "" {
-		p := parseInt(priorityStr)
-		priority = &p
+	query := r.URL.Query()
+	req := dto.ListTasksRequest{
+		TaskFilterParams: parseTaskFilterParams(query),
+		Sort:             query.Get("sort"),
+		Direction:        query.Get("direction"),
+		Cursor:           query.Get("cursor"),
+	}
+	if blocked != nil {
+		req.Status = domain.TaskStatusPending
+		req.Blocked = blocked
 	}
 
-	var projectIDPtr *string
-	if projectID != "" {
-		projectIDPtr = &projectID
+	limit, err := parseLimit(query)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid limit")
+		return
 	}
+	req.Limit = limit
 
 	// Get tasks
-	tasks, err := h.getUserTasksUC.Execute(r.Context(), userID, status, priority, projectIDPtr)
+	page, err := h.getUserTasksUC.Execute(r.Context(), userID, req)
 	if err != nil {
-		h.respondWithError(w, http.StatusInternalServerError, "failed to get tasks")
+		h.respondWithTaskQueryError(w, err, "failed to get tasks")
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusOK, map[string]interface{}{
-		"data":  tasks,
-		"total": len(tasks),
-	})
+	h.respondWithJSON(w, http.StatusOK, page)
 }
 
 func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
@@ -156,14 +222,76 @@ func (h *TaskHandler) UpdateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ifMatch, ok := h.parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	// Update task
-	task, err := h.updateTaskUC.Execute(r.Context(), taskID, userID, req)
+	req.Force = parseForce(r.URL.Query())
+	task, err := h.updateTaskUC.Execute(r.Context(), taskID, userID, req, ifMatch)
+	if err != nil {
+		h.respondWithTaskWriteError(w, err, "failed to update task")
+		return
+	}
+
+	h.respondWithTask(w, http.StatusOK, task)
+}
+
+// maxPatchBodySize bounds the body of PATCH /tasks/{id}
+const maxPatchBodySize = 1 << 20
+
+// PatchTask handles PATCH /tasks/{id} with a JSON Merge Patch
+// (application/merge-patch+json, or plain application/json) or a JSON Patch
+// (application/json-patch+json)
+func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	contentType := patch.MergePatchContentType
+	if header := r.Header.Get("Content-Type"); header != "" {
+		mediaType, _, err := mime.ParseMediaType(header)
+		if err != nil {
+			h.respondWithError(w, http.StatusUnsupportedMediaType, "invalid Content-Type")
+			return
+		}
+		switch mediaType {
+		case patch.MergePatchContentType, "application/json":
+		case patch.JSONPatchContentType:
+			contentType = patch.JSONPatchContentType
+		default:
+			h.respondWithError(w, http.StatusUnsupportedMediaType, "Content-Type must be "+patch.MergePatchContentType+" or "+patch.JSONPatchContentType)
+			return
+		}
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPatchBodySize))
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	ifMatch, ok := h.parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	// Patch task
+	req := dto.PatchTaskRequest{ContentType: contentType, Body: body, Force: parseForce(r.URL.Query())}
+	task, err := h.patchTaskUC.Execute(r.Context(), taskID, userID, req, ifMatch)
 	if err != nil {
-		h.respondWithError(w, http.StatusInternalServerError, "failed to update task")
+		h.respondWithTaskWriteError(w, err, "failed to patch task")
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusOK, task)
+	h.respondWithTask(w, http.StatusOK, task)
 }
 
 func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
@@ -177,38 +305,483 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	ifMatch, ok := h.parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
 	// Delete task
-	if err := h.deleteTaskUC.Execute(r.Context(), taskID, userID); err != nil {
-		h.respondWithError(w, http.StatusInternalServerError, "failed to delete task")
+	if err := h.deleteTaskUC.Execute(r.Context(), taskID, userID, ifMatch); err != nil {
+		h.respondWithTaskWriteError(w, err, "failed to delete task")
 		return
 	}
 
 	h.respondWithJSON(w, http.StatusOK, map[string]string{"message": "task deleted", "id": taskID})
 }
 
+// maxBatchBodySize bounds the body of POST /tasks/batch
+const maxBatchBodySize = 4 << 20
+
+// BatchTasks handles POST /tasks/batch
+func (h *TaskHandler) BatchTasks(w http.ResponseWriter, r *http.Request) {
+	var req dto.BatchTasksRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBatchBodySize)).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"user_id":    userID,
+		"mode":       req.Mode,
+		"operations": len(req.Operations),
+	}).Info("running task batch")
+
+	// Run operations
+	response, err := h.batchTasksUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		var opErr *usecase.BatchOperationError
+		if errors.As(err, &opErr) && opErr.Status != http.StatusInternalServerError {
+			h.respondWithJSON(w, opErr.Status, map[string]interface{}{
+				"error": opErr.Message,
+				"index": opErr.Index,
+			})
+			return
+		}
+		h.respondWithUseCaseError(w, err, "failed to run batch")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, response)
+}
+
+// QuickAddTask handles POST /tasks/quick
+func (h *TaskHandler) QuickAddTask(w http.ResponseWriter, r *http.Request) {
+	var req dto.QuickAddTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	h.logger.WithFields(map[string]interface{}{
+		"user_id":  userID,
+		"timezone": req.Timezone,
+	}).Info("quick adding task")
+
+	// Create task
+	response, err := h.quickAddUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to create task")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, response)
+}
+
+// GetTrash handles GET /tasks/trash
+func (h *TaskHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get trashed tasks
+	trash, err := h.getTrashUC.Execute(r.Context(), userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get trash")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, trash)
+}
+
+// RestoreTask handles POST /tasks/{id}/restore
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Restore task from the trash
+	task, err := h.restoreTaskUC.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to restore task")
+		return
+	}
+
+	h.respondWithTask(w, http.StatusOK, task)
+}
+
+// CloseTask handles POST /tasks/{id}/close
+func (h *TaskHandler) CloseTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	ifMatch, ok := h.parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	// Complete task
+	task, err := h.closeTaskUC.Execute(r.Context(), taskID, userID, parseForce(r.URL.Query()), ifMatch)
+	if err != nil {
+		h.respondWithTaskWriteError(w, err, "failed to close task")
+		return
+	}
+
+	h.respondWithTask(w, http.StatusOK, task)
+}
+
+// ReopenTask handles POST /tasks/{id}/reopen
+func (h *TaskHandler) ReopenTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	ifMatch, ok := h.parseIfMatch(w, r)
+	if !ok {
+		return
+	}
+
+	// Reopen task
+	task, err := h.reopenTaskUC.Execute(r.Context(), taskID, userID, ifMatch)
+	if err != nil {
+		h.respondWithTaskWriteError(w, err, "failed to reopen task")
+		return
+	}
+
+	h.respondWithTask(w, http.StatusOK, task)
+}
+
+// GetCompletedTasks handles GET /tasks/completed
+func (h *TaskHandler) GetCompletedTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	req := dto.CompletedTasksRequest{
+		From:     query.Get("from"),
+		To:       query.Get("to"),
+		Timezone: query.Get("timezone"),
+		Cursor:   query.Get("cursor"),
+	}
+	if projectID := query.Get("project_id"); projectID != "" {
+		req.ProjectID = &projectID
+	}
+
+	limit, err := parseLimit(query)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	req.Limit = limit
+
+	// Get completed tasks
+	page, err := h.getCompletedUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get completed tasks")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, page)
+}
+
+func (h *TaskHandler) CreateSubtask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	parentID := vars["id"]
+
+	var req dto.CreateTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Create subtask
+	req.ParentID = &parentID
+	task, err := h.createTaskUC.Execute(r.Context(), req, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to create subtask")
+		return
+	}
+
+	h.respondWithTask(w, http.StatusCreated, task)
+}
+
+func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	var req dto.MoveTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Move task with its subtree
+	task, err := h.moveTaskUC.Execute(r.Context(), taskID, userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to move task")
+		return
+	}
+
+	h.respondWithTask(w, http.StatusOK, task)
+}
+
+func (h *TaskHandler) GetTaskTree(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get task with descendants
+	tree, err := h.getTaskTreeUC.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get task tree")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, tree)
+}
+
+func (h *TaskHandler) GetTaskCompletions(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get completion history
+	completions, err := h.completionsUC.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get task completions")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"data":  completions,
+		"total": len(completions),
+	})
+}
+
 func (h *TaskHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	h.respondWithJSON(w, http.StatusOK, map[string]string{"status": "healthy"})
 }
 
-func (h *TaskHandler) respondWithError(w http.ResponseWriter, code int, message string) {
-	h.respondWithJSON(w, code, map[string]string{"error": message})
+// SearchTasks handles GET /tasks/search
+func (h *TaskHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Parse query parameters
+	query := r.URL.Query()
+	req := dto.SearchTasksRequest{
+		TaskFilterParams: parseTaskFilterParams(query),
+		Query:            query.Get("q"),
+	}
+
+	limit, err := parseLimit(query)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid limit")
+		return
+	}
+	req.Limit = limit
+
+	// Search tasks
+	results, err := h.searchTasksUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		h.respondWithTaskQueryError(w, err, "failed to search tasks")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"data":  results,
+		"total": len(results),
+	})
 }
 
-func (h *TaskHandler) respondWithJSON(w http.ResponseWriter, code int, payload interface{}) {
-	response, _ := json.Marshal(payload)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(code)
-	w.Write(response)
+// respondWithTaskQueryError reports invalid filter expressions with their
+// position and every other error like respondWithUseCaseError
+func (h *TaskHandler) respondWithTaskQueryError(w http.ResponseWriter, err error, fallback string) {
+	var parseErr *filter.ParseError
+	if errors.As(err, &parseErr) {
+		h.respondWithJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"error":   "invalid filter",
+			"details": parseErr,
+		})
+		return
+	}
+	h.respondWithUseCaseError(w, err, fallback)
 }
 
-func (h *TaskHandler) getUserIDFromToken(r *http.Request) (string, error) {
-	// Get user ID from header set by API gateway
-	userID := r.Header.Get("X-User-ID")
-	if userID == "" {
-		return "", fmt.Errorf("X-User-ID header is required")
+// parseTaskFilterParams reads the filtering parameters shared by task
+// listings and search
+func parseTaskFilterParams(query url.Values) dto.TaskFilterParams {
+	params := dto.TaskFilterParams{
+		Status:   query.Get("status"),
+		Filter:   query.Get("filter"),
+		Timezone: query.Get("timezone"),
+	}
+
+	if priorityStr := query.Get("priority"); priorityStr != "" {
+		p := parseInt(priorityStr)
+		params.Priority = &p
+	}
+
+	if projectID := query.Get("project_id"); projectID != "" {
+		params.ProjectID = &projectID
+	}
+
+	if labelsStr := query.Get("labels"); labelsStr != "" {
+		params.Labels = strings.Split(labelsStr, ",")
+	}
+
+	if blocked, err := strconv.ParseBool(query.Get("blocked")); err == nil {
+		params.Blocked = &blocked
 	}
 
-	return userID, nil
+	return params
+}
+
+// parseForce reads the force parameter of writes that can complete a task
+func parseForce(query url.Values) bool {
+	force, _ := strconv.ParseBool(query.Get("force"))
+	return force
+}
+
+// parseLimit reads the optional limit parameter
+func parseLimit(query url.Values) (int, error) {
+	limitStr := query.Get("limit")
+	if limitStr == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(limitStr)
+}
+
+// respondWithTask writes a task with its version as ETag
+func (h *TaskHandler) respondWithTask(w http.ResponseWriter, code int, task *dto.TaskResponse) {
+	w.Header().Set("ETag", taskETag(task))
+	h.respondWithJSON(w, code, task)
+}
+
+// respondWithTaskWriteError answers a failed If-Match precondition with 412
+// and the current task, and every other error like respondWithUseCaseError
+func (h *TaskHandler) respondWithTaskWriteError(w http.ResponseWriter, err error, fallback string) {
+	var preconditionErr *usecase.PreconditionFailedError
+	if errors.As(err, &preconditionErr) {
+		h.respondWithTask(w, http.StatusPreconditionFailed, preconditionErr.Current)
+		return
+	}
+	h.respondWithUseCaseError(w, err, fallback)
+}
+
+// parseIfMatch reads the If-Match header as a task version. "*" and a
+// missing header match any version, unless the handler requires If-Match.
+// It responds to the request itself when the header is unusable.
+func (h *TaskHandler) parseIfMatch(w http.ResponseWriter, r *http.Request) (*int, bool) {
+	header := strings.TrimSpace(r.Header.Get("If-Match"))
+	if header == "" {
+		if h.requireIfMatch {
+			h.respondWithError(w, http.StatusPreconditionRequired, "If-Match header is required")
+			return nil, false
+		}
+		return nil, true
+	}
+	if header == "*" {
+		return nil, true
+	}
+
+	// Versions are compared exactly, so weak validators are accepted as is
+	tag := strings.TrimPrefix(header, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		h.respondWithError(w, http.StatusBadRequest, "If-Match must be a single ETag")
+		return nil, false
+	}
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "If-Match must be a single ETag")
+		return nil, false
+	}
+	return &version, true
+}
+
+// taskETag is the entity tag of a task version
+func taskETag(task *dto.TaskResponse) string {
+	return fmt.Sprintf("%q", strconv.Itoa(task.Version))
 }
 
 func parseInt(s string) int {
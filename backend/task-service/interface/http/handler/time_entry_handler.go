@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/todoist/backend/pkg/logger"
+	"github.com/todoist/backend/pkg/validator"
+	"github.com/todoist/backend/task-service/application/dto"
+	"github.com/todoist/backend/task-service/application/usecase"
+	"github.com/todoist/backend/task-service/domain"
+)
+
+type TimeEntryHandler struct {
+	baseHandler
+	validator            *validator.Validator
+	startTimerUC         *usecase.StartTimerUseCase
+	stopTimerUC          *usecase.StopTimerUseCase
+	createTimeEntryUC    *usecase.CreateTimeEntryUseCase
+	getTaskTimeEntriesUC *usecase.GetTaskTimeEntriesUseCase
+	deleteTimeEntryUC    *usecase.DeleteTimeEntryUseCase
+	getTimeReportUC      *usecase.GetTimeReportUseCase
+}
+
+func NewTimeEntryHandler(
+	v *validator.Validator,
+	log *logger.Logger,
+	taskRepo domain.TaskRepository,
+	timeEntryRepo domain.TimeEntryRepository,
+) *TimeEntryHandler {
+	return &TimeEntryHandler{
+		baseHandler:          baseHandler{logger: log},
+		validator:            v,
+		startTimerUC:         usecase.NewStartTimerUseCase(taskRepo, timeEntryRepo),
+		stopTimerUC:          usecase.NewStopTimerUseCase(taskRepo, timeEntryRepo),
+		createTimeEntryUC:    usecase.NewCreateTimeEntryUseCase(taskRepo, timeEntryRepo),
+		getTaskTimeEntriesUC: usecase.NewGetTaskTimeEntriesUseCase(taskRepo, timeEntryRepo),
+		deleteTimeEntryUC:    usecase.NewDeleteTimeEntryUseCase(taskRepo, timeEntryRepo),
+		getTimeReportUC:      usecase.NewGetTimeReportUseCase(timeEntryRepo),
+	}
+}
+
+// StartTimer handles POST /tasks/{id}/timer/start; the body is optional
+func (h *TimeEntryHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	var req dto.StartTimerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Start timer
+	entry, err := h.startTimerUC.Execute(r.Context(), taskID, userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to start timer")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, entry)
+}
+
+// StopTimer handles POST /tasks/{id}/timer/stop
+func (h *TimeEntryHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Stop timer
+	entry, err := h.stopTimerUC.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to stop timer")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, entry)
+}
+
+// CreateTimeEntry handles POST /tasks/{id}/time-entries
+func (h *TimeEntryHandler) CreateTimeEntry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	var req dto.CreateTimeEntryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.validator.Validate(req); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Record time entry
+	entry, err := h.createTimeEntryUC.Execute(r.Context(), taskID, userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to create time entry")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, entry)
+}
+
+// GetTaskTimeEntries handles GET /tasks/{id}/time-entries
+func (h *TimeEntryHandler) GetTaskTimeEntries(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Get time entries
+	entries, trackedSeconds, err := h.getTaskTimeEntriesUC.Execute(r.Context(), taskID, userID)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get time entries")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]interface{}{
+		"data":            entries,
+		"total":           len(entries),
+		"tracked_seconds": trackedSeconds,
+	})
+}
+
+// DeleteTimeEntry handles DELETE /tasks/{id}/time-entries/{entryId}
+func (h *TimeEntryHandler) DeleteTimeEntry(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	taskID := vars["id"]
+	entryID := vars["entryId"]
+
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	// Delete time entry
+	if err := h.deleteTimeEntryUC.Execute(r.Context(), taskID, entryID, userID); err != nil {
+		h.respondWithUseCaseError(w, err, "failed to delete time entry")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, map[string]string{"message": "time entry deleted", "id": entryID})
+}
+
+// GetTimeReport handles GET /tasks/time-report
+func (h *TimeEntryHandler) GetTimeReport(w http.ResponseWriter, r *http.Request) {
+	// Get user ID from JWT token
+	userID, err := h.getUserIDFromToken(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	query := r.URL.Query()
+	req := dto.TimeReportRequest{
+		GroupBy:  query.Get("group_by"),
+		From:     query.Get("from"),
+		To:       query.Get("to"),
+		Timezone: query.Get("timezone"),
+	}
+
+	// Build report
+	report, err := h.getTimeReportUC.Execute(r.Context(), userID, req)
+	if err != nil {
+		h.respondWithUseCaseError(w, err, "failed to get time report")
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, report)
+}
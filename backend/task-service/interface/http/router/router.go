@@ -1,13 +1,16 @@
 package router
 
 import (
+	"net/http"
+
 	"github.com/gorilla/mux"
+	"github.com/todoist/backend/pkg/deadline"
 	"github.com/todoist/backend/pkg/logger"
 	"github.com/todoist/backend/task-service/interface/http/handler"
 	"github.com/todoist/backend/task-service/interface/http/middleware"
 )
 
-func NewRouter(taskHandler *handler.TaskHandler, log *logger.Logger) *mux.Router {
+func NewRouter(taskHandler *handler.TaskHandler, labelHandler *handler.LabelHandler, commentHandler *handler.CommentHandler, activityHandler *handler.ActivityHandler, reminderHandler *handler.ReminderHandler, dependencyHandler *handler.DependencyHandler, timeEntryHandler *handler.TimeEntryHandler, attachmentHandler *handler.AttachmentHandler, syncHandler *handler.SyncHandler, statsHandler *handler.StatsHandler, importHandler *handler.ImportHandler, calendarHandler *handler.CalendarHandler, log *logger.Logger) *mux.Router {
 	r := mux.NewRouter()
 
 	// Apply middleware
@@ -17,12 +20,85 @@ func NewRouter(taskHandler *handler.TaskHandler, log *logger.Logger) *mux.Router
 	// Health check
 	r.HandleFunc("/health", taskHandler.HealthCheck).Methods("GET")
 
+	// Sync route
+	r.HandleFunc("/sync", syncHandler.Sync).Methods("POST")
+
+	// Label routes (registered before /tasks/{id} so "labels" is not taken as a task ID)
+	r.HandleFunc("/tasks/labels", labelHandler.CreateLabel).Methods("POST")
+	r.HandleFunc("/tasks/labels", labelHandler.GetUserLabels).Methods("GET")
+	r.HandleFunc("/tasks/labels/{labelId}", labelHandler.GetLabel).Methods("GET")
+	r.HandleFunc("/tasks/labels/{labelId}", labelHandler.UpdateLabel).Methods("PUT")
+	r.HandleFunc("/tasks/labels/{labelId}", labelHandler.DeleteLabel).Methods("DELETE")
+
+	// Signed attachment downloads (registered before /tasks/{id}; the URL signature authenticates them)
+	r.PathPrefix("/tasks/attachments/blobs/").HandlerFunc(attachmentHandler.ServeBlob).Methods("GET", "HEAD")
+
 	// Task routes
 	r.HandleFunc("/tasks", taskHandler.CreateTask).Methods("POST")
 	r.HandleFunc("/tasks", taskHandler.GetUserTasks).Methods("GET")
+	r.HandleFunc("/tasks/batch", taskHandler.BatchTasks).Methods("POST")
+	r.HandleFunc("/tasks/quick", taskHandler.QuickAddTask).Methods("POST")
+	r.Handle("/tasks/import", deadline.Middleware(handler.ImportTimeout, log)(http.HandlerFunc(importHandler.ImportTasks))).Methods("POST")
+	r.HandleFunc("/tasks/search", taskHandler.SearchTasks).Methods("GET")
+	r.HandleFunc("/tasks/trash", taskHandler.GetTrash).Methods("GET")
+	r.HandleFunc("/tasks/completed", taskHandler.GetCompletedTasks).Methods("GET")
+	r.HandleFunc("/tasks/blocked", taskHandler.GetBlockedTasks).Methods("GET")
+	r.HandleFunc("/tasks/ready", taskHandler.GetReadyTasks).Methods("GET")
+	r.HandleFunc("/tasks/time-report", timeEntryHandler.GetTimeReport).Methods("GET")
+	r.HandleFunc("/tasks/activity", activityHandler.GetUserActivity).Methods("GET")
+	r.HandleFunc("/tasks/stats", statsHandler.GetStats).Methods("GET")
+	r.HandleFunc("/tasks/stats/goals", statsHandler.UpdateGoals).Methods("PUT")
+	r.HandleFunc("/tasks/calendar.ics", calendarHandler.GetFeed).Methods("GET")
+	r.HandleFunc("/tasks/calendar/token", calendarHandler.CreateToken).Methods("POST")
+	r.HandleFunc("/tasks/calendar/token", calendarHandler.RevokeToken).Methods("DELETE")
 	r.HandleFunc("/tasks/{id}", taskHandler.GetTask).Methods("GET")
 	r.HandleFunc("/tasks/{id}", taskHandler.UpdateTask).Methods("PUT")
+	r.HandleFunc("/tasks/{id}", taskHandler.PatchTask).Methods("PATCH")
 	r.HandleFunc("/tasks/{id}", taskHandler.DeleteTask).Methods("DELETE")
+	r.HandleFunc("/tasks/{id}/restore", taskHandler.RestoreTask).Methods("POST")
+	r.HandleFunc("/tasks/{id}/close", taskHandler.CloseTask).Methods("POST")
+	r.HandleFunc("/tasks/{id}/reopen", taskHandler.ReopenTask).Methods("POST")
+
+	// Task hierarchy routes
+	r.HandleFunc("/tasks/{id}/subtasks", taskHandler.CreateSubtask).Methods("POST")
+	r.HandleFunc("/tasks/{id}/tree", taskHandler.GetTaskTree).Methods("GET")
+	r.HandleFunc("/tasks/{id}/move", taskHandler.MoveTask).Methods("POST")
+
+	// Comment routes
+	r.HandleFunc("/tasks/{id}/comments", commentHandler.AddComment).Methods("POST")
+	r.HandleFunc("/tasks/{id}/comments", commentHandler.GetTaskComments).Methods("GET")
+	r.HandleFunc("/tasks/{id}/comments/{commentId}", commentHandler.UpdateComment).Methods("PUT")
+	r.HandleFunc("/tasks/{id}/comments/{commentId}", commentHandler.DeleteComment).Methods("DELETE")
+
+	// Reminder routes
+	r.HandleFunc("/tasks/{id}/reminders", reminderHandler.CreateReminder).Methods("POST")
+	r.HandleFunc("/tasks/{id}/reminders", reminderHandler.GetTaskReminders).Methods("GET")
+	r.HandleFunc("/tasks/{id}/reminders/{reminderId}", reminderHandler.DeleteReminder).Methods("DELETE")
+
+	// Dependency routes
+	r.HandleFunc("/tasks/{id}/dependencies", dependencyHandler.AddDependency).Methods("POST")
+	r.HandleFunc("/tasks/{id}/dependencies", dependencyHandler.GetTaskDependencies).Methods("GET")
+	r.HandleFunc("/tasks/{id}/dependencies/{blockerId}", dependencyHandler.RemoveDependency).Methods("DELETE")
+
+	// Time tracking routes
+	r.HandleFunc("/tasks/{id}/timer/start", timeEntryHandler.StartTimer).Methods("POST")
+	r.HandleFunc("/tasks/{id}/timer/stop", timeEntryHandler.StopTimer).Methods("POST")
+	r.HandleFunc("/tasks/{id}/time-entries", timeEntryHandler.CreateTimeEntry).Methods("POST")
+	r.HandleFunc("/tasks/{id}/time-entries", timeEntryHandler.GetTaskTimeEntries).Methods("GET")
+	r.HandleFunc("/tasks/{id}/time-entries/{entryId}", timeEntryHandler.DeleteTimeEntry).Methods("DELETE")
+
+	// Attachment routes
+	r.Handle("/tasks/{id}/attachments", deadline.Middleware(handler.UploadTimeout, log)(http.HandlerFunc(attachmentHandler.UploadAttachment))).Methods("POST")
+	r.HandleFunc("/tasks/{id}/attachments", attachmentHandler.GetTaskAttachments).Methods("GET")
+	r.HandleFunc("/tasks/{id}/attachments/{attachmentId}", attachmentHandler.GetAttachment).Methods("GET")
+	r.HandleFunc("/tasks/{id}/attachments/{attachmentId}", attachmentHandler.DeleteAttachment).Methods("DELETE")
+	r.HandleFunc("/tasks/{id}/attachments/{attachmentId}/download", attachmentHandler.DownloadAttachment).Methods("GET")
+
+	// Activity history routes
+	r.HandleFunc("/tasks/{id}/activity", activityHandler.GetTaskActivity).Methods("GET")
+
+	// Recurring task routes
+	r.HandleFunc("/tasks/{id}/completions", taskHandler.GetTaskCompletions).Methods("GET")
 
 	return r
 }